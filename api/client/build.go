@@ -4,12 +4,14 @@ import (
 	"archive/tar"
 	"bufio"
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"strings"
 
 	"golang.org/x/net/context"
 
@@ -20,10 +22,12 @@ import (
 	"github.com/docker/docker/opts"
 	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/fileutils"
+	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/jsonmessage"
 	flag "github.com/docker/docker/pkg/mflag"
 	"github.com/docker/docker/pkg/progress"
 	"github.com/docker/docker/pkg/streamformatter"
+	"github.com/docker/docker/pkg/system"
 	"github.com/docker/docker/pkg/urlutil"
 	"github.com/docker/docker/reference"
 	runconfigopts "github.com/docker/docker/runconfig/opts"
@@ -58,9 +62,12 @@ func (cli *DockerCli) CmdBuild(args ...string) error {
 	flCPUSetCpus := cmd.String([]string{"-cpuset-cpus"}, "", "CPUs in which to allow execution (0-3, 0,1)")
 	flCPUSetMems := cmd.String([]string{"-cpuset-mems"}, "", "MEMs in which to allow execution (0-3, 0,1)")
 	flCgroupParent := cmd.String([]string{"-cgroup-parent"}, "", "Optional parent cgroup for the container")
+	flNetworkMode := cmd.String([]string{"-network"}, "default", "Set the networking mode for the RUN instructions during build")
 	flBuildArg := opts.NewListOpts(runconfigopts.ValidateEnv)
 	cmd.Var(&flBuildArg, []string{"-build-arg"}, "Set build-time variables")
 	isolation := cmd.String([]string{"-isolation"}, "", "Container isolation technology")
+	flOutputs := opts.NewListOpts(nil)
+	cmd.Var(&flOutputs, []string{"-output"}, "Export the build result to a local directory or tarball instead of an image (type=local|tar,dest=path[,src=path])")
 
 	flLabels := opts.NewListOpts(nil)
 	cmd.Var(&flLabels, []string{"-label"}, "Set metadata for an image")
@@ -98,6 +105,24 @@ func (cli *DockerCli) CmdBuild(args ...string) error {
 		buildBuff = bytes.NewBuffer(nil)
 	}
 
+	outputs, err := parseBuildOutputs(flOutputs.GetAll())
+	if err != nil {
+		return err
+	}
+	if len(outputs) > 0 {
+		if *suppressOutput {
+			return fmt.Errorf("--output cannot be used with --quiet")
+		}
+		if flTags.Len() > 0 {
+			return fmt.Errorf("--output cannot be used with --tag")
+		}
+		if outputs[0].Type == "tar" && outputs[0].Attrs["dest"] == "-" {
+			// The archive is written to stdout, keep the progress out of it.
+			progBuff = cli.err
+			buildBuff = cli.err
+		}
+	}
+
 	switch {
 	case specifiedContext == "-":
 		ctx, relDockerfile, err = builder.GetContextFromReader(cli.in, *dockerfileName)
@@ -227,12 +252,35 @@ func (cli *DockerCli) CmdBuild(args ...string) error {
 		CPUQuota:       *flCPUQuota,
 		CPUPeriod:      *flCPUPeriod,
 		CgroupParent:   *flCgroupParent,
+		NetworkMode:    *flNetworkMode,
 		Dockerfile:     relDockerfile,
 		ShmSize:        shmSize,
 		Ulimits:        flUlimits.GetList(),
 		BuildArgs:      runconfigopts.ConvertKVStringsToMap(flBuildArg.GetAll()),
 		AuthConfigs:    cli.retrieveAuthConfigs(),
 		Labels:         runconfigopts.ConvertKVStringsToMap(flLabels.GetAll()),
+		Outputs:        outputs,
+	}
+
+	var (
+		auxCallback func(*json.RawMessage)
+		outputErr   error
+		waitOutput  = func() error { return nil }
+	)
+	if len(outputs) > 0 {
+		var outputWriter io.WriteCloser
+		outputWriter, waitOutput, err = cli.buildOutputWriter(outputs[0])
+		if err != nil {
+			return err
+		}
+		defer outputWriter.Close()
+		auxCallback = func(aux *json.RawMessage) {
+			var chunk builder.OutputChunk
+			if err := json.Unmarshal(*aux, &chunk); err != nil || outputErr != nil {
+				return
+			}
+			_, outputErr = outputWriter.Write(chunk.Data)
+		}
 	}
 
 	response, err := cli.client.ImageBuild(context.Background(), body, options)
@@ -241,7 +289,7 @@ func (cli *DockerCli) CmdBuild(args ...string) error {
 	}
 	defer response.Body.Close()
 
-	err = jsonmessage.DisplayJSONMessagesStream(response.Body, buildBuff, cli.outFd, cli.isTerminalOut, nil)
+	err = jsonmessage.DisplayJSONMessagesStream(response.Body, buildBuff, cli.outFd, cli.isTerminalOut, auxCallback)
 	if err != nil {
 		if jerr, ok := err.(*jsonmessage.JSONError); ok {
 			// If no error code is set, default to 1
@@ -267,6 +315,15 @@ func (cli *DockerCli) CmdBuild(args ...string) error {
 		fmt.Fprintf(cli.out, "%s", buildBuff)
 	}
 
+	if len(outputs) > 0 {
+		if outputErr != nil {
+			return fmt.Errorf("failed to write build output: %v", outputErr)
+		}
+		if err := waitOutput(); err != nil {
+			return fmt.Errorf("failed to write build output: %v", err)
+		}
+	}
+
 	if isTrusted() {
 		// Since the build was successful, now we must tag any of the resolved
 		// images from the above Dockerfile rewrite.
@@ -280,6 +337,100 @@ func (cli *DockerCli) CmdBuild(args ...string) error {
 	return nil
 }
 
+// parseBuildOutputs parses the values of the --output flag. A value is
+// either a comma-separated list of key=value pairs, of which "type" is
+// required, or a plain path which is a shorthand for "type=local,dest=path".
+func parseBuildOutputs(values []string) ([]types.ImageBuildOutput, error) {
+	var outputs []types.ImageBuildOutput
+	for _, value := range values {
+		if !strings.Contains(value, "=") {
+			outputs = append(outputs, types.ImageBuildOutput{
+				Type:  "local",
+				Attrs: map[string]string{"dest": value},
+			})
+			continue
+		}
+
+		output := types.ImageBuildOutput{Attrs: map[string]string{}}
+		for _, field := range strings.Split(value, ",") {
+			parts := strings.SplitN(field, "=", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("invalid output field %q, must be a key=value pair", field)
+			}
+			key, val := strings.ToLower(strings.TrimSpace(parts[0])), strings.TrimSpace(parts[1])
+			if key == "type" {
+				output.Type = val
+				continue
+			}
+			output.Attrs[key] = val
+		}
+
+		switch output.Type {
+		case "local", "tar":
+		case "":
+			return nil, fmt.Errorf("output type is required: %q", value)
+		default:
+			return nil, fmt.Errorf("unsupported output type %q", output.Type)
+		}
+		if output.Attrs["dest"] == "" {
+			return nil, fmt.Errorf("output destination is required: %q", value)
+		}
+		if output.Type == "local" && output.Attrs["dest"] == "-" {
+			return nil, fmt.Errorf("a local output cannot be written to stdout, use type=tar")
+		}
+		outputs = append(outputs, output)
+	}
+	if len(outputs) > 1 {
+		return nil, fmt.Errorf("only one output can be specified")
+	}
+	return outputs, nil
+}
+
+// buildOutputWriter returns a writer for the archive streamed back by the
+// daemon for the given build output, and a function that waits until the
+// archive has been completely written to its destination once the writer
+// is closed.
+func (cli *DockerCli) buildOutputWriter(output types.ImageBuildOutput) (io.WriteCloser, func() error, error) {
+	dest := output.Attrs["dest"]
+	pr, pw := io.Pipe()
+	errCh := make(chan error, 1)
+
+	switch output.Type {
+	case "local":
+		if err := system.MkdirAll(dest, 0755); err != nil {
+			return nil, nil, err
+		}
+		go func() {
+			err := archive.Untar(pr, dest, &archive.TarOptions{NoLchown: true})
+			pr.CloseWithError(err)
+			errCh <- err
+		}()
+	case "tar":
+		var out io.WriteCloser = ioutils.NopWriteCloser(cli.out)
+		if dest != "-" {
+			f, err := os.Create(dest)
+			if err != nil {
+				return nil, nil, err
+			}
+			out = f
+		}
+		go func() {
+			_, err := io.Copy(out, pr)
+			if cerr := out.Close(); err == nil {
+				err = cerr
+			}
+			pr.CloseWithError(err)
+			errCh <- err
+		}()
+	}
+
+	wait := func() error {
+		pw.Close()
+		return <-errCh
+	}
+	return pw, wait, nil
+}
+
 // validateTag checks if the given image name can be resolved.
 func validateTag(rawRepo string) (string, error) {
 	_, err := reference.ParseNamed(rawRepo)
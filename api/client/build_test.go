@@ -0,0 +1,48 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/engine-api/types"
+)
+
+func TestParseBuildOutputs(t *testing.T) {
+	valids := map[string]types.ImageBuildOutput{
+		"./out": {
+			Type:  "local",
+			Attrs: map[string]string{"dest": "./out"},
+		},
+		"type=local,dest=/tmp/out": {
+			Type:  "local",
+			Attrs: map[string]string{"dest": "/tmp/out"},
+		},
+		"type=tar,dest=-,src=/go/bin": {
+			Type:  "tar",
+			Attrs: map[string]string{"dest": "-", "src": "/go/bin"},
+		},
+	}
+	for value, expected := range valids {
+		outputs, err := parseBuildOutputs([]string{value})
+		if err != nil {
+			t.Fatalf("Unexpected error parsing %q: %v", value, err)
+		}
+		if len(outputs) != 1 || !reflect.DeepEqual(outputs[0], expected) {
+			t.Fatalf("Expected %v for %q, got %v", expected, value, outputs)
+		}
+	}
+
+	invalids := [][]string{
+		{"dest=./out"},
+		{"type=image,dest=./out"},
+		{"type=local"},
+		{"type=local,dest=-"},
+		{"type=tar,dest"},
+		{"./out", "./out2"},
+	}
+	for _, values := range invalids {
+		if _, err := parseBuildOutputs(values); err == nil {
+			t.Fatalf("Expected an error parsing %v", values)
+		}
+	}
+}
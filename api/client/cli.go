@@ -6,7 +6,9 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/docker/docker/api"
 	"github.com/docker/docker/cli"
@@ -44,6 +46,10 @@ type DockerCli struct {
 	isTerminalIn bool
 	// isTerminalOut indicates whether the client's STDOUT is a TTY
 	isTerminalOut bool
+	// host is the address of the daemon the client connects to.
+	host string
+	// tlsOptions holds the TLS options to connect to the daemon, if any.
+	tlsOptions *tlsconfig.Options
 	// client is the http client that performs all API operations
 	client client.APIClient
 	// state holds the terminal state
@@ -83,6 +89,48 @@ func (cli *DockerCli) ImagesFormat() string {
 	return cli.configFile.ImagesFormat
 }
 
+// VolumesFormat returns the format string specified in the configuration
+// for the output of `docker volume ls`.
+func (cli *DockerCli) VolumesFormat() string {
+	return cli.configFile.VolumesFormat
+}
+
+// NetworksFormat returns the format string specified in the configuration
+// for the output of `docker network ls`.
+func (cli *DockerCli) NetworksFormat() string {
+	return cli.configFile.NetworksFormat
+}
+
+// StatsFormat returns the format string specified in the configuration
+// for the output of `docker stats`.
+func (cli *DockerCli) StatsFormat() string {
+	return cli.configFile.StatsFormat
+}
+
+// EventsFormat returns the format string specified in the configuration
+// for the output of `docker events`.
+func (cli *DockerCli) EventsFormat() string {
+	return cli.configFile.EventsFormat
+}
+
+// HistoryFormat returns the format string specified in the configuration
+// for the output of `docker history`.
+func (cli *DockerCli) HistoryFormat() string {
+	return cli.configFile.HistoryFormat
+}
+
+// SearchFormat returns the format string specified in the configuration
+// for the output of `docker search`.
+func (cli *DockerCli) SearchFormat() string {
+	return cli.configFile.SearchFormat
+}
+
+// InfoFormat returns the format string specified in the configuration
+// for the output of `docker info`.
+func (cli *DockerCli) InfoFormat() string {
+	return cli.configFile.InfoFormat
+}
+
 func (cli *DockerCli) setRawTerminal() error {
 	if cli.isTerminalIn && os.Getenv("NORAW") == "" {
 		state, err := term.SetRawTerminal(cli.inFd)
@@ -131,10 +179,12 @@ func NewDockerCli(in io.ReadCloser, out, err io.Writer, clientFlags *cli.ClientF
 		}
 		cli.configFile = configFile
 
-		host, err := getServerHost(clientFlags.Common.Hosts, clientFlags.Common.TLSOptions)
+		host, tlsOptions, err := getServerEndpoint(clientFlags, configFile)
 		if err != nil {
 			return err
 		}
+		cli.host = host
+		cli.tlsOptions = tlsOptions
 
 		customHeaders := cli.configFile.HTTPHeaders
 		if customHeaders == nil {
@@ -147,7 +197,7 @@ func NewDockerCli(in io.ReadCloser, out, err io.Writer, clientFlags *cli.ClientF
 			verStr = tmpStr
 		}
 
-		httpClient, err := newHTTPClient(host, clientFlags.Common.TLSOptions)
+		httpClient, err := newHTTPClient(host, tlsOptions)
 		if err != nil {
 			return err
 		}
@@ -171,6 +221,59 @@ func NewDockerCli(in io.ReadCloser, out, err io.Writer, clientFlags *cli.ClientF
 	return cli
 }
 
+// getServerEndpoint returns the host of the daemon to connect to, and the TLS
+// options to connect to it. The host is either specified with -H or
+// DOCKER_HOST, or is the one of an endpoint of the configuration file. The
+// endpoint is selected with --context or DOCKER_CONTEXT, and defaults to the
+// default endpoint of the configuration file.
+func getServerEndpoint(clientFlags *cli.ClientFlags, configFile *cliconfig.ConfigFile) (string, *tlsconfig.Options, error) {
+	name := clientFlags.Context
+	if name != "" && len(clientFlags.Common.Hosts) > 0 {
+		return "", nil, errors.New("Please specify only one of -H and --context")
+	}
+	if name == "" && len(clientFlags.Common.Hosts) == 0 && os.Getenv("DOCKER_HOST") == "" {
+		name = os.Getenv("DOCKER_CONTEXT")
+		if name == "" {
+			name = configFile.DefaultEndpoint
+		}
+	}
+
+	if name == "" {
+		host, err := getServerHost(clientFlags.Common.Hosts, clientFlags.Common.TLSOptions)
+		return host, clientFlags.Common.TLSOptions, err
+	}
+
+	endpoint, err := configFile.Endpoint(name)
+	if err != nil {
+		return "", nil, err
+	}
+	tlsOptions := endpointTLSOptions(endpoint, cliconfig.ConfigDir())
+	host, err := getServerHost([]string{endpoint.Host}, tlsOptions)
+	return host, tlsOptions, err
+}
+
+// endpointTLSOptions returns the TLS options to connect to the endpoint, or
+// nil if it does not use TLS. The TLS options of the command line do not
+// apply to endpoints.
+func endpointTLSOptions(endpoint cliconfig.Endpoint, configDir string) *tlsconfig.Options {
+	if !endpoint.TLS && !endpoint.TLSVerify {
+		return nil
+	}
+
+	path := func(p string) string {
+		if p == "" || filepath.IsAbs(p) {
+			return p
+		}
+		return filepath.Join(configDir, p)
+	}
+	return &tlsconfig.Options{
+		CAFile:             path(endpoint.CAFile),
+		CertFile:           path(endpoint.CertFile),
+		KeyFile:            path(endpoint.KeyFile),
+		InsecureSkipVerify: !endpoint.TLSVerify,
+	}
+}
+
 func getServerHost(hosts []string, tlsOptions *tlsconfig.Options) (host string, err error) {
 	switch len(hosts) {
 	case 0:
@@ -181,11 +284,30 @@ func getServerHost(hosts []string, tlsOptions *tlsconfig.Options) (host string,
 		return "", errors.New("Please specify only one -H")
 	}
 
+	if strings.HasPrefix(host, "ssh://") {
+		_, err = parseSSHHost(strings.TrimPrefix(host, "ssh://"))
+		return host, err
+	}
+
 	host, err = opts.ParseHost(tlsOptions != nil, host)
 	return
 }
 
 func newHTTPClient(host string, tlsOptions *tlsconfig.Options) (*http.Client, error) {
+	if strings.HasPrefix(host, "ssh://") {
+		// The connection is secured by ssh, the TLS options don't apply.
+		dial, err := sshDial(strings.TrimPrefix(host, "ssh://"))
+		if err != nil {
+			return nil, err
+		}
+		return &http.Client{
+			Transport: &http.Transport{
+				Dial:               dial,
+				DisableCompression: true,
+			},
+		}, nil
+	}
+
 	if tlsOptions == nil {
 		// let the api client configure the default transport.
 		return nil, nil
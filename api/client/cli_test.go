@@ -0,0 +1,109 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cliconfig"
+	"github.com/docker/go-connections/tlsconfig"
+)
+
+func TestGetServerEndpoint(t *testing.T) {
+	defer os.Setenv("DOCKER_HOST", os.Getenv("DOCKER_HOST"))
+	defer os.Setenv("DOCKER_CONTEXT", os.Getenv("DOCKER_CONTEXT"))
+
+	configFile := &cliconfig.ConfigFile{
+		Endpoints: map[string]cliconfig.Endpoint{
+			"staging":    {Host: "tcp://staging.example.com:2375"},
+			"production": {Host: "tcp://production.example.com:2376", TLSVerify: true, CAFile: "production/ca.pem"},
+		},
+		DefaultEndpoint: "staging",
+	}
+	flagsTLSOptions := &tlsconfig.Options{CAFile: "/flags/ca.pem"}
+
+	cases := []struct {
+		hosts       []string
+		context     string
+		envHost     string
+		envContext  string
+		expHost     string
+		expTLS      bool
+		expError    string
+		description string
+	}{
+		{nil, "", "", "", "tcp://staging.example.com:2375", false, "", "default endpoint"},
+		{[]string{"tcp://flag.example.com:2375"}, "", "", "", "tcp://flag.example.com:2375", true, "", "-H overrides the default endpoint"},
+		{nil, "", "tcp://env.example.com:2375", "", "tcp://env.example.com:2375", true, "", "DOCKER_HOST overrides the default endpoint"},
+		{nil, "production", "", "", "tcp://production.example.com:2376", true, "", "--context"},
+		{nil, "", "", "production", "tcp://production.example.com:2376", true, "", "DOCKER_CONTEXT"},
+		{nil, "staging", "", "production", "tcp://staging.example.com:2375", false, "", "--context overrides DOCKER_CONTEXT"},
+		{nil, "staging", "tcp://env.example.com:2375", "", "tcp://staging.example.com:2375", false, "", "--context overrides DOCKER_HOST"},
+		{[]string{"tcp://flag.example.com:2375"}, "staging", "", "", "", false, "Please specify only one of -H and --context", "-H and --context"},
+		{nil, "unknown", "", "", "", false, `endpoint "unknown" is not defined in the client configuration`, "unknown endpoint"},
+	}
+
+	for _, c := range cases {
+		os.Setenv("DOCKER_HOST", c.envHost)
+		os.Setenv("DOCKER_CONTEXT", c.envContext)
+		clientFlags := &cli.ClientFlags{
+			Common: &cli.CommonFlags{
+				Hosts:      c.hosts,
+				TLSOptions: flagsTLSOptions,
+			},
+			Context: c.context,
+		}
+
+		host, tlsOptions, err := getServerEndpoint(clientFlags, configFile)
+		if c.expError != "" {
+			if err == nil || err.Error() != c.expError {
+				t.Fatalf("%s: expected error %q, got %v", c.description, c.expError, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", c.description, err)
+		}
+		if host != c.expHost {
+			t.Fatalf("%s: expected host %s, got %s", c.description, c.expHost, host)
+		}
+		if (tlsOptions != nil) != c.expTLS {
+			t.Fatalf("%s: expected TLS %v, got %v", c.description, c.expTLS, tlsOptions)
+		}
+	}
+}
+
+func TestEndpointTLSOptions(t *testing.T) {
+	if tlsOptions := endpointTLSOptions(cliconfig.Endpoint{Host: "tcp://example.com"}, "/config"); tlsOptions != nil {
+		t.Fatalf("expected no TLS options, got %v", tlsOptions)
+	}
+
+	endpoint := cliconfig.Endpoint{
+		Host:     "tcp://example.com",
+		TLS:      true,
+		CAFile:   "example/ca.pem",
+		CertFile: "/etc/docker/cert.pem",
+	}
+	tlsOptions := endpointTLSOptions(endpoint, "/config")
+	if tlsOptions == nil {
+		t.Fatal("expected TLS options")
+	}
+	if tlsOptions.CAFile != filepath.Join("/config", "example/ca.pem") {
+		t.Fatalf("expected the CA file to be relative to the config dir, got %s", tlsOptions.CAFile)
+	}
+	if tlsOptions.CertFile != "/etc/docker/cert.pem" {
+		t.Fatalf("expected the absolute cert file to be kept, got %s", tlsOptions.CertFile)
+	}
+	if tlsOptions.KeyFile != "" {
+		t.Fatalf("expected no key file, got %s", tlsOptions.KeyFile)
+	}
+	if !tlsOptions.InsecureSkipVerify {
+		t.Fatal("expected the remote not to be verified without tlsverify")
+	}
+
+	endpoint.TLSVerify = true
+	if tlsOptions := endpointTLSOptions(endpoint, "/config"); tlsOptions.InsecureSkipVerify {
+		t.Fatal("expected the remote to be verified with tlsverify")
+	}
+}
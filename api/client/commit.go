@@ -3,9 +3,11 @@ package client
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 
 	"golang.org/x/net/context"
 
+	"github.com/docker/docker/builder/dockerignore"
 	Cli "github.com/docker/docker/cli"
 	"github.com/docker/docker/opts"
 	flag "github.com/docker/docker/pkg/mflag"
@@ -23,6 +25,9 @@ func (cli *DockerCli) CmdCommit(args ...string) error {
 	flAuthor := cmd.String([]string{"a", "-author"}, "", "Author (e.g., \"John Hannibal Smith <hannibal@a-team.com>\")")
 	flChanges := opts.NewListOpts(nil)
 	cmd.Var(&flChanges, []string{"c", "-change"}, "Apply Dockerfile instruction to the created image")
+	flExclude := opts.NewListOpts(nil)
+	cmd.Var(&flExclude, []string{"-exclude"}, "Exclude paths matching a pattern from the created image")
+	flExcludeFrom := cmd.String([]string{"-exclude-from"}, "", "Read the patterns of the paths to exclude from a file, in the .dockerignore format")
 	// FIXME: --run is deprecated, it will be replaced with inline Dockerfile commands.
 	flConfig := cmd.String([]string{"#-run"}, "", "This option is deprecated and will be removed in a future version in favor of inline Dockerfile-compatible commands")
 	cmd.Require(flag.Max, 2)
@@ -43,6 +48,19 @@ func (cli *DockerCli) CmdCommit(args ...string) error {
 		}
 	}
 
+	excludes := flExclude.GetAll()
+	if *flExcludeFrom != "" {
+		f, err := os.Open(*flExcludeFrom)
+		if err != nil {
+			return err
+		}
+		patterns, err := dockerignore.ReadAll(f)
+		if err != nil {
+			return err
+		}
+		excludes = append(patterns, excludes...)
+	}
+
 	options := types.ContainerCommitOptions{
 		Reference: reference,
 		Comment:   *flComment,
@@ -50,6 +68,7 @@ func (cli *DockerCli) CmdCommit(args ...string) error {
 		Changes:   flChanges.GetAll(),
 		Pause:     *flPause,
 		Config:    config,
+		Exclude:   excludes,
 	}
 
 	response, err := cli.client.ContainerCommit(context.Background(), name, options)
@@ -26,6 +26,7 @@ const (
 
 type cpConfig struct {
 	followLink bool
+	copyUIDGID bool
 }
 
 // CmdCp copies files/folders to or from a path in a container.
@@ -37,13 +38,16 @@ type cpConfig struct {
 // archive file from STDIN, and the destination CONTAINER:DEST_PATH, must specify
 // a directory.
 //
+// When copying between containers, the daemon copies the data directly.
+//
 // Usage:
 // 	docker cp CONTAINER:SRC_PATH DEST_PATH|-
 // 	docker cp SRC_PATH|- CONTAINER:DEST_PATH
+// 	docker cp CONTAINER:SRC_PATH CONTAINER:DEST_PATH
 func (cli *DockerCli) CmdCp(args ...string) error {
 	cmd := Cli.Subcmd(
 		"cp",
-		[]string{"CONTAINER:SRC_PATH DEST_PATH|-", "SRC_PATH|- CONTAINER:DEST_PATH"},
+		[]string{"CONTAINER:SRC_PATH DEST_PATH|-", "SRC_PATH|- CONTAINER:DEST_PATH", "CONTAINER:SRC_PATH CONTAINER:DEST_PATH"},
 		strings.Join([]string{
 			Cli.DockerCommands["cp"].Description,
 			"\nUse '-' as the source to read a tar archive from stdin\n",
@@ -55,6 +59,7 @@ func (cli *DockerCli) CmdCp(args ...string) error {
 	)
 
 	followLink := cmd.Bool([]string{"L", "-follow-link"}, false, "Always follow symbol link in SRC_PATH")
+	copyUIDGID := cmd.Bool([]string{"a", "-archive"}, false, "Archive mode (copy all uid/gid information)")
 
 	cmd.Require(flag.Exact, 2)
 	cmd.ParseFlags(args, true)
@@ -79,6 +84,7 @@ func (cli *DockerCli) CmdCp(args ...string) error {
 
 	cpParam := &cpConfig{
 		followLink: *followLink,
+		copyUIDGID: *copyUIDGID,
 	}
 
 	switch direction {
@@ -87,8 +93,7 @@ func (cli *DockerCli) CmdCp(args ...string) error {
 	case toContainer:
 		return cli.copyToContainer(srcPath, dstContainer, dstPath, cpParam)
 	case acrossContainers:
-		// Copying between containers isn't supported.
-		return fmt.Errorf("copying between containers is not supported")
+		return cli.copyBetweenContainers(srcContainer, srcPath, dstContainer, dstPath, cpParam)
 	default:
 		// User didn't specify any container.
 		return fmt.Errorf("must specify at least one container source")
@@ -289,7 +294,16 @@ func (cli *DockerCli) copyToContainer(srcPath, dstContainer, dstPath string, cpP
 
 	options := types.CopyToContainerOptions{
 		AllowOverwriteDirWithFile: false,
+		CopyUIDGID:                cpParam.copyUIDGID,
 	}
 
 	return cli.client.CopyToContainer(context.Background(), dstContainer, resolvedDstPath, content, options)
 }
+
+func (cli *DockerCli) copyBetweenContainers(srcContainer, srcPath, dstContainer, dstPath string, cpParam *cpConfig) error {
+	options := types.CopyBetweenContainersOptions{
+		FollowLink: cpParam.followLink,
+		CopyUIDGID: cpParam.copyUIDGID,
+	}
+	return cli.client.CopyBetweenContainers(context.Background(), srcContainer, srcPath, dstContainer, dstPath, options)
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	Cli "github.com/docker/docker/cli"
+	flag "github.com/docker/docker/pkg/mflag"
+	"github.com/docker/engine-api/client"
+	"github.com/docker/engine-api/types"
+	"github.com/docker/go-connections/sockets"
+	"github.com/docker/go-connections/tlsconfig"
+)
+
+// CmdDialStdio proxies the standard input and output to the daemon.
+//
+// It is run on the remote host by clients connecting to a daemon with an
+// ssh:// host, and is not listed in the commands of the client.
+//
+// Usage: docker dial-stdio
+func (cli *DockerCli) CmdDialStdio(args ...string) error {
+	cmd := Cli.Subcmd("dial-stdio", nil, "Proxy the standard input and output to the daemon", true)
+	cmd.Require(flag.Exact, 0)
+
+	cmd.ParseFlags(args, true)
+
+	conn, err := cli.dialDaemon()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	go func() {
+		io.Copy(conn, cli.in)
+		// Let the daemon know the client is done sending, the response is
+		// still read until the daemon closes the connection.
+		if cw, ok := conn.(types.CloseWriter); ok {
+			cw.CloseWrite()
+		}
+	}()
+
+	_, err = io.Copy(cli.out, conn)
+	return err
+}
+
+// dialDaemon connects to the daemon the client is configured for.
+func (cli *DockerCli) dialDaemon() (net.Conn, error) {
+	proto, addr, _, err := client.ParseHost(cli.host)
+	if err != nil {
+		return nil, err
+	}
+
+	switch proto {
+	case "unix":
+		return net.Dial(proto, addr)
+	case "npipe":
+		return sockets.DialPipe(addr, 32*time.Second)
+	case "tcp":
+		if cli.tlsOptions == nil {
+			return net.Dial(proto, addr)
+		}
+		config, err := tlsconfig.Client(*cli.tlsOptions)
+		if err != nil {
+			return nil, err
+		}
+		return tls.Dial(proto, addr, config)
+	}
+	return nil, fmt.Errorf("cannot proxy to the daemon at %s", cli.host)
+}
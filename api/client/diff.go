@@ -1,24 +1,29 @@
 package client
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 
 	"golang.org/x/net/context"
 
 	Cli "github.com/docker/docker/cli"
 	"github.com/docker/docker/pkg/archive"
 	flag "github.com/docker/docker/pkg/mflag"
+	"github.com/docker/engine-api/types"
 )
 
 // CmdDiff shows changes on a container's filesystem.
 //
 // Each changed file is printed on a separate line, prefixed with a single
 // character that indicates the status of the file: C (modified), A (added),
-// or D (deleted).
+// or D (deleted). With --details, the mode, size and sha256 of the added and
+// modified files are printed before their path.
 //
-// Usage: docker diff CONTAINER
+// Usage: docker diff [OPTIONS] CONTAINER
 func (cli *DockerCli) CmdDiff(args ...string) error {
 	cmd := Cli.Subcmd("diff", []string{"CONTAINER"}, Cli.DockerCommands["diff"].Description, true)
+	details := cmd.Bool([]string{"-details"}, false, "Show the mode, size and sha256 of the changed files")
 	cmd.Require(flag.Exact, 1)
 
 	cmd.ParseFlags(args, true)
@@ -27,23 +32,63 @@ func (cli *DockerCli) CmdDiff(args ...string) error {
 		return fmt.Errorf("Container name cannot be empty")
 	}
 
+	if *details {
+		return cli.diffDetails(cmd.Arg(0))
+	}
+
 	changes, err := cli.client.ContainerDiff(context.Background(), cmd.Arg(0))
 	if err != nil {
 		return err
 	}
 
 	for _, change := range changes {
-		var kind string
-		switch change.Kind {
-		case archive.ChangeModify:
-			kind = "C"
-		case archive.ChangeAdd:
-			kind = "A"
-		case archive.ChangeDelete:
-			kind = "D"
-		}
-		fmt.Fprintf(cli.out, "%s %s\n", kind, change.Path)
+		fmt.Fprintf(cli.out, "%s %s\n", changeKind(change.Kind), change.Path)
 	}
 
 	return nil
 }
+
+// diffDetails prints the changes of the container as they are streamed by
+// the daemon, with the mode, size and sha256 of the files. Fields the daemon
+// did not set, like the sha256 of a directory, are printed as "-".
+func (cli *DockerCli) diffDetails(container string) error {
+	responseBody, err := cli.client.ContainerDiffDetails(context.Background(), container)
+	if err != nil {
+		return err
+	}
+	defer responseBody.Close()
+
+	dec := json.NewDecoder(responseBody)
+	for {
+		var change types.ContainerChange
+		if err := dec.Decode(&change); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		mode, size, sum := "-", "-", "-"
+		if change.Kind != archive.ChangeDelete {
+			mode = change.Mode.String()
+			size = fmt.Sprintf("%d", change.Size)
+		}
+		if change.Sha256 != "" {
+			sum = change.Sha256
+		}
+		fmt.Fprintf(cli.out, "%s %s %s %s %s\n", changeKind(change.Kind), mode, size, sum, change.Path)
+	}
+}
+
+// changeKind returns the character printed for the kind of a change.
+func changeKind(kind int) string {
+	switch kind {
+	case archive.ChangeModify:
+		return "C"
+	case archive.ChangeAdd:
+		return "A"
+	case archive.ChangeDelete:
+		return "D"
+	}
+	return ""
+}
@@ -4,17 +4,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"sort"
 	"strings"
 	"sync"
-	"time"
 
 	"golang.org/x/net/context"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/client/formatter"
 	Cli "github.com/docker/docker/cli"
 	"github.com/docker/docker/opts"
-	"github.com/docker/docker/pkg/jsonlog"
 	flag "github.com/docker/docker/pkg/mflag"
 	"github.com/docker/engine-api/types"
 	eventtypes "github.com/docker/engine-api/types/events"
@@ -28,12 +26,21 @@ func (cli *DockerCli) CmdEvents(args ...string) error {
 	cmd := Cli.Subcmd("events", nil, Cli.DockerCommands["events"].Description, true)
 	since := cmd.String([]string{"-since"}, "", "Show all events created since timestamp")
 	until := cmd.String([]string{"-until"}, "", "Stream events until this timestamp")
+	format := cmd.String([]string{"-format"}, "", "Format the output using the given go template")
 	flFilter := opts.NewListOpts(nil)
 	cmd.Var(&flFilter, []string{"f", "-filter"}, "Filter output based on conditions provided")
 	cmd.Require(flag.Exact, 0)
 
 	cmd.ParseFlags(args, true)
 
+	f := *format
+	if len(f) == 0 {
+		f = cli.EventsFormat()
+	}
+	if strings.HasPrefix(f, "table") {
+		return fmt.Errorf("the table format is not supported by events, they are streamed")
+	}
+
 	eventFilterArgs := filters.NewArgs()
 
 	// Consolidate all filter flags, and sanity check them early.
@@ -58,16 +65,24 @@ func (cli *DockerCli) CmdEvents(args ...string) error {
 	}
 	defer responseBody.Close()
 
-	return streamEvents(responseBody, cli.out)
+	return streamEvents(responseBody, cli.out, f)
 }
 
-// streamEvents decodes prints the incoming events in the provided output.
-func streamEvents(input io.Reader, output io.Writer) error {
+// streamEvents decodes prints the incoming events in the provided output,
+// using the given format.
+func streamEvents(input io.Reader, output io.Writer, format string) error {
 	return decodeEvents(input, func(event eventtypes.Message, err error) error {
 		if err != nil {
 			return err
 		}
-		printOutput(event, output)
+		eventCtx := formatter.EventContext{
+			Context: formatter.Context{
+				Output: output,
+				Format: format,
+			},
+			Events: []eventtypes.Message{event},
+		}
+		eventCtx.Write()
 		return nil
 	})
 }
@@ -90,34 +105,6 @@ func decodeEvents(input io.Reader, ep eventProcessor) error {
 	return nil
 }
 
-// printOutput prints all types of event information.
-// Each output includes the event type, actor id, name and action.
-// Actor attributes are printed at the end if the actor has any.
-func printOutput(event eventtypes.Message, output io.Writer) {
-	if event.TimeNano != 0 {
-		fmt.Fprintf(output, "%s ", time.Unix(0, event.TimeNano).Format(jsonlog.RFC3339NanoFixed))
-	} else if event.Time != 0 {
-		fmt.Fprintf(output, "%s ", time.Unix(event.Time, 0).Format(jsonlog.RFC3339NanoFixed))
-	}
-
-	fmt.Fprintf(output, "%s %s %s", event.Type, event.Action, event.Actor.ID)
-
-	if len(event.Actor.Attributes) > 0 {
-		var attrs []string
-		var keys []string
-		for k := range event.Actor.Attributes {
-			keys = append(keys, k)
-		}
-		sort.Strings(keys)
-		for _, k := range keys {
-			v := event.Actor.Attributes[k]
-			attrs = append(attrs, fmt.Sprintf("%s=%s", k, v))
-		}
-		fmt.Fprintf(output, " (%s)", strings.Join(attrs, ", "))
-	}
-	fmt.Fprint(output, "\n")
-}
-
 type eventHandler struct {
 	handlers map[string]func(eventtypes.Message)
 	mu       sync.Mutex
@@ -8,8 +8,10 @@ import (
 
 	"github.com/Sirupsen/logrus"
 	Cli "github.com/docker/docker/cli"
+	"github.com/docker/docker/opts"
 	flag "github.com/docker/docker/pkg/mflag"
 	"github.com/docker/docker/pkg/promise"
+	runconfigopts "github.com/docker/docker/runconfig/opts"
 	"github.com/docker/engine-api/types"
 )
 
@@ -128,8 +130,12 @@ func ParseExec(cmd *flag.FlagSet, args []string) (*types.ExecConfig, error) {
 		flDetach     = cmd.Bool([]string{"d", "-detach"}, false, "Detached mode: run command in the background")
 		flUser       = cmd.String([]string{"u", "-user"}, "", "Username or UID (format: <name|uid>[:<group|gid>])")
 		flPrivileged = cmd.Bool([]string{"-privileged"}, false, "Give extended privileges to the command")
+		flWorkingDir = cmd.String([]string{"w", "-workdir"}, "", "Working directory inside the container")
+		flTimeout    = cmd.Int([]string{"-timeout"}, 0, "Kill the command after a timeout in seconds, 0 for no timeout")
+		flEnv        = opts.NewListOpts(runconfigopts.ValidateEnv)
 		execCmd      []string
 	)
+	cmd.Var(&flEnv, []string{"e", "-env"}, "Set environment variables")
 	cmd.Require(flag.Min, 2)
 	if err := cmd.ParseFlags(args, true); err != nil {
 		return nil, err
@@ -143,6 +149,9 @@ func ParseExec(cmd *flag.FlagSet, args []string) (*types.ExecConfig, error) {
 		Tty:        *flTty,
 		Cmd:        execCmd,
 		Detach:     *flDetach,
+		Env:        flEnv.GetAll(),
+		WorkingDir: *flWorkingDir,
+		Timeout:    *flTimeout,
 	}
 
 	// If -d is not set, attach to everything by default
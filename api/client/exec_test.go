@@ -15,9 +15,10 @@ type arguments struct {
 
 func TestParseExec(t *testing.T) {
 	invalids := map[*arguments]error{
-		&arguments{[]string{"-unknown"}}: fmt.Errorf("flag provided but not defined: -unknown"),
-		&arguments{[]string{"-u"}}:       fmt.Errorf("flag needs an argument: -u"),
-		&arguments{[]string{"--user"}}:   fmt.Errorf("flag needs an argument: --user"),
+		&arguments{[]string{"-unknown"}}:                                  fmt.Errorf("flag provided but not defined: -unknown"),
+		&arguments{[]string{"-u"}}:                                        fmt.Errorf("flag needs an argument: -u"),
+		&arguments{[]string{"--user"}}:                                    fmt.Errorf("flag needs an argument: --user"),
+		&arguments{[]string{"--timeout", "soon", "container", "command"}}: fmt.Errorf("invalid value \"soon\" for flag --timeout: strconv.ParseInt: parsing \"soon\": invalid syntax"),
 	}
 	valids := map[*arguments]*types.ExecConfig{
 		&arguments{
@@ -44,6 +45,16 @@ func TestParseExec(t *testing.T) {
 			Tty:          true,
 			Cmd:          []string{"command"},
 		},
+		&arguments{
+			[]string{"-e", "FOO=bar", "--env", "BAZ", "-w", "/tmp", "--timeout", "10", "container", "command"},
+		}: {
+			AttachStdout: true,
+			AttachStderr: true,
+			Env:          []string{"FOO=bar", "BAZ"},
+			WorkingDir:   "/tmp",
+			Timeout:      10,
+			Cmd:          []string{"command"},
+		},
 		&arguments{
 			[]string{"-d", "container", "command"},
 		}: {
@@ -110,6 +121,20 @@ func compareExecConfig(config1 *types.ExecConfig, config2 *types.ExecConfig) boo
 	if config1.User != config2.User {
 		return false
 	}
+	if config1.WorkingDir != config2.WorkingDir {
+		return false
+	}
+	if config1.Timeout != config2.Timeout {
+		return false
+	}
+	if len(config1.Env) != len(config2.Env) {
+		return false
+	}
+	for index, value := range config1.Env {
+		if value != config2.Env[index] {
+			return false
+		}
+	}
 	if len(config1.Cmd) != len(config2.Cmd) {
 		return false
 	}
@@ -0,0 +1,115 @@
+package formatter
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/pkg/jsonlog"
+	eventtypes "github.com/docker/engine-api/types/events"
+)
+
+const (
+	// defaultEventFormat prints the event type, action and actor id,
+	// followed by the attributes of the actor if it has any.
+	defaultEventFormat = "{{with .Time}}{{.}} {{end}}{{.Type}} {{.Action}} {{.ID}}{{with .Attributes}} ({{.}}){{end}}"
+)
+
+// EventContext contains event specific information required by the
+// formatter, encapsulate a Context struct. Events are streamed, so they
+// cannot be printed as a table.
+type EventContext struct {
+	Context
+	// Events
+	Events []eventtypes.Message
+}
+
+func (ctx EventContext) Write() {
+	switch ctx.Format {
+	case jsonFormatKey:
+		var elements []interface{}
+		for _, event := range ctx.Events {
+			elements = append(elements, event)
+		}
+		ctx.writeJSON(elements)
+		return
+	case "":
+		ctx.Format = defaultEventFormat
+	case rawFormatKey:
+		ctx.Format = `time: {{.Time}}
+type: {{.Type}}
+action: {{.Action}}
+id: {{.ID}}
+attributes: {{.Attributes}}
+`
+	}
+
+	ctx.buffer = bytes.NewBufferString("")
+	ctx.preformat()
+
+	tmpl, err := ctx.parseFormat()
+	if err != nil {
+		return
+	}
+
+	for _, event := range ctx.Events {
+		eventCtx := &eventContext{
+			e: event,
+		}
+		err = ctx.contextFormat(tmpl, eventCtx)
+		if err != nil {
+			return
+		}
+	}
+
+	ctx.buffer.WriteTo(ctx.Output)
+}
+
+type eventContext struct {
+	baseSubContext
+	e eventtypes.Message
+}
+
+func (c *eventContext) Time() string {
+	if c.e.TimeNano != 0 {
+		return time.Unix(0, c.e.TimeNano).Format(jsonlog.RFC3339NanoFixed)
+	}
+	if c.e.Time != 0 {
+		return time.Unix(c.e.Time, 0).Format(jsonlog.RFC3339NanoFixed)
+	}
+	return ""
+}
+
+func (c *eventContext) Type() string {
+	return c.e.Type
+}
+
+func (c *eventContext) Action() string {
+	return c.e.Action
+}
+
+func (c *eventContext) ID() string {
+	return c.e.Actor.ID
+}
+
+// Attributes returns the attributes of the actor sorted by key, as a comma
+// separated list of key=value pairs.
+func (c *eventContext) Attributes() string {
+	var keys []string
+	for k := range c.e.Actor.Attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var attrs []string
+	for _, k := range keys {
+		attrs = append(attrs, fmt.Sprintf("%s=%s", k, c.e.Actor.Attributes[k]))
+	}
+	return strings.Join(attrs, ", ")
+}
+
+func (c *eventContext) Attribute(name string) string {
+	return c.e.Actor.Attributes[name]
+}
@@ -0,0 +1,111 @@
+package formatter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/pkg/jsonlog"
+	eventtypes "github.com/docker/engine-api/types/events"
+)
+
+func TestEventContextWrite(t *testing.T) {
+	timeNano := time.Now().UnixNano()
+	expectedTime := time.Unix(0, timeNano).Format(jsonlog.RFC3339NanoFixed)
+
+	contexts := []struct {
+		context  EventContext
+		expected string
+	}{
+		// Errors
+		{
+			EventContext{
+				Context: Context{
+					Format: "{{InvalidFunction}}",
+				},
+			},
+			`Template parsing error: template: :1: function "InvalidFunction" not defined
+`,
+		},
+		// Default format
+		{
+			EventContext{},
+			expectedTime + " container create containerID1 (image=busybox, name=foobar_baz)\n" +
+				"image pull busybox\n",
+		},
+		// Raw Format
+		{
+			EventContext{
+				Context: Context{
+					Format: "raw",
+				},
+			},
+			`time: ` + expectedTime + `
+type: container
+action: create
+id: containerID1
+attributes: image=busybox, name=foobar_baz
+
+time: 
+type: image
+action: pull
+id: busybox
+attributes: 
+
+`,
+		},
+		// Custom Format
+		{
+			EventContext{
+				Context: Context{
+					Format: `{{.Action}} {{.Attribute "name"}}`,
+				},
+			},
+			"create foobar_baz\npull \n",
+		},
+	}
+
+	for _, context := range contexts {
+		events := []eventtypes.Message{
+			{
+				Type:     eventtypes.ContainerEventType,
+				Action:   "create",
+				Actor:    eventtypes.Actor{ID: "containerID1", Attributes: map[string]string{"name": "foobar_baz", "image": "busybox"}},
+				TimeNano: timeNano,
+			},
+			{
+				Type:   eventtypes.ImageEventType,
+				Action: "pull",
+				Actor:  eventtypes.Actor{ID: "busybox"},
+			},
+		}
+		out := bytes.NewBufferString("")
+		context.context.Output = out
+		context.context.Events = events
+		context.context.Write()
+		actual := out.String()
+		if actual != context.expected {
+			t.Fatalf("Expected \n%s, got \n%s", context.expected, actual)
+		}
+	}
+}
+
+func TestEventContextWriteJSON(t *testing.T) {
+	out := bytes.NewBufferString("")
+	ctx := EventContext{
+		Context: Context{
+			Format: "json",
+			Output: out,
+		},
+		Events: []eventtypes.Message{
+			{Type: eventtypes.NetworkEventType, Action: "connect", Actor: eventtypes.Actor{ID: "networkID1"}},
+		},
+	}
+	ctx.Write()
+
+	expected := `{"Type":"network","Action":"connect","Actor":{"ID":"networkID1","Attributes":null}}`
+	if actual := strings.TrimSpace(out.String()); actual != expected {
+		t.Fatalf("Expected %s, got %s", expected, actual)
+	}
+}
@@ -2,6 +2,7 @@ package formatter
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
@@ -16,6 +17,7 @@ import (
 const (
 	tableFormatKey = "table"
 	rawFormatKey   = "raw"
+	jsonFormatKey  = "json"
 
 	defaultContainerTableFormat       = "table {{.ID}}\t{{.Image}}\t{{.Command}}\t{{.RunningFor}} ago\t{{.Status}}\t{{.Ports}}\t{{.Names}}"
 	defaultImageTableFormat           = "table {{.Repository}}\t{{.Tag}}\t{{.ID}}\t{{.CreatedSince}} ago\t{{.Size}}"
@@ -81,6 +83,17 @@ func (c *Context) postformat(tmpl *template.Template, subContext subContext) {
 	}
 }
 
+// writeJSON writes each element as a JSON document on its own line.
+func (c *Context) writeJSON(elements []interface{}) {
+	enc := json.NewEncoder(c.Output)
+	for _, e := range elements {
+		if err := enc.Encode(e); err != nil {
+			fmt.Fprintf(c.Output, "JSON encoding error: %v\n", err)
+			return
+		}
+	}
+}
+
 func (c *Context) contextFormat(tmpl *template.Template, subContext subContext) error {
 	if err := tmpl.Execute(c.buffer, subContext); err != nil {
 		c.buffer = bytes.NewBufferString(fmt.Sprintf("Template parsing error: %v\n", err))
@@ -113,6 +126,13 @@ type ImageContext struct {
 
 func (ctx ContainerContext) Write() {
 	switch ctx.Format {
+	case jsonFormatKey:
+		var elements []interface{}
+		for _, container := range ctx.Containers {
+			elements = append(elements, container)
+		}
+		ctx.writeJSON(elements)
+		return
 	case tableFormatKey:
 		ctx.Format = defaultContainerTableFormat
 		if ctx.Quiet {
@@ -165,6 +185,13 @@ ports: {{.Ports}}
 
 func (ctx ImageContext) Write() {
 	switch ctx.Format {
+	case jsonFormatKey:
+		var elements []interface{}
+		for _, image := range ctx.Images {
+			elements = append(elements, image)
+		}
+		ctx.writeJSON(elements)
+		return
 	case tableFormatKey:
 		ctx.Format = defaultImageTableFormat
 		if ctx.Digest {
@@ -2,6 +2,7 @@ package formatter
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
@@ -533,3 +534,57 @@ func TestImageContextWriteWithNoImage(t *testing.T) {
 		out.Reset()
 	}
 }
+
+func TestContainerContextWriteJSON(t *testing.T) {
+	containers := []types.Container{
+		{ID: "containerID1", Names: []string{"/foobar_baz"}, Image: "ubuntu"},
+		{ID: "containerID2", Names: []string{"/foobar_bar"}, Image: "ubuntu"},
+	}
+	out := bytes.NewBufferString("")
+	ctx := ContainerContext{
+		Context: Context{
+			Format: "json",
+			Output: out,
+		},
+		Containers: containers,
+	}
+	ctx.Write()
+
+	dec := json.NewDecoder(out)
+	for _, expected := range containers {
+		var c types.Container
+		if err := dec.Decode(&c); err != nil {
+			t.Fatal(err)
+		}
+		if c.ID != expected.ID || c.Image != expected.Image {
+			t.Fatalf("Expected %v, got %v", expected, c)
+		}
+	}
+}
+
+func TestImageContextWriteJSON(t *testing.T) {
+	images := []types.Image{
+		{ID: "imageID1", RepoTags: []string{"image:tag1"}},
+		{ID: "imageID2", RepoTags: []string{"image:tag2"}},
+	}
+	out := bytes.NewBufferString("")
+	ctx := ImageContext{
+		Context: Context{
+			Format: "json",
+			Output: out,
+		},
+		Images: images,
+	}
+	ctx.Write()
+
+	dec := json.NewDecoder(out)
+	for _, expected := range images {
+		var i types.Image
+		if err := dec.Decode(&i); err != nil {
+			t.Fatal(err)
+		}
+		if i.ID != expected.ID || i.RepoTags[0] != expected.RepoTags[0] {
+			t.Fatalf("Expected %v, got %v", expected, i)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package formatter
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/docker/pkg/stringutils"
+	"github.com/docker/engine-api/types"
+	"github.com/docker/go-units"
+)
+
+const (
+	defaultHistoryTableFormat = "table {{.ID}}\t{{.CreatedSince}}\t{{.CreatedBy}}\t{{.Size}}\t{{.Comment}}"
+
+	createdByHeader = "CREATED BY"
+	commentHeader   = "COMMENT"
+)
+
+// HistoryContext contains image history specific information required by the
+// formatter, encapsulate a Context struct.
+type HistoryContext struct {
+	Context
+	// Human when set to true will print sizes and dates in human readable format.
+	Human bool
+	// History
+	History []types.ImageHistory
+}
+
+func (ctx HistoryContext) Write() {
+	switch ctx.Format {
+	case jsonFormatKey:
+		var elements []interface{}
+		for _, entry := range ctx.History {
+			elements = append(elements, entry)
+		}
+		ctx.writeJSON(elements)
+		return
+	case tableFormatKey:
+		if ctx.Quiet {
+			ctx.Format = defaultQuietFormat
+		} else {
+			ctx.Format = defaultHistoryTableFormat
+		}
+	case rawFormatKey:
+		if ctx.Quiet {
+			ctx.Format = `image_id: {{.ID}}`
+		} else {
+			ctx.Format = `image_id: {{.ID}}
+created_at: {{.CreatedAt}}
+created_by: {{.CreatedBy}}
+size: {{.Size}}
+comment: {{.Comment}}
+`
+		}
+	}
+
+	ctx.buffer = bytes.NewBufferString("")
+	ctx.preformat()
+
+	tmpl, err := ctx.parseFormat()
+	if err != nil {
+		return
+	}
+
+	for _, entry := range ctx.History {
+		historyCtx := &historyContext{
+			trunc: ctx.Trunc,
+			human: ctx.Human,
+			h:     entry,
+		}
+		err = ctx.contextFormat(tmpl, historyCtx)
+		if err != nil {
+			return
+		}
+	}
+
+	ctx.postformat(tmpl, &historyContext{})
+}
+
+type historyContext struct {
+	baseSubContext
+	trunc bool
+	human bool
+	h     types.ImageHistory
+}
+
+func (c *historyContext) ID() string {
+	c.addHeader(imageHeader)
+	if c.trunc {
+		return stringid.TruncateID(c.h.ID)
+	}
+	return c.h.ID
+}
+
+func (c *historyContext) CreatedSince() string {
+	c.addHeader(createdSinceHeader)
+	createdAt := time.Unix(c.h.Created, 0)
+	if !c.human {
+		return createdAt.Format(time.RFC3339)
+	}
+	return units.HumanDuration(time.Now().UTC().Sub(createdAt)) + " ago"
+}
+
+func (c *historyContext) CreatedAt() string {
+	c.addHeader(createdAtHeader)
+	return time.Unix(c.h.Created, 0).String()
+}
+
+func (c *historyContext) CreatedBy() string {
+	c.addHeader(createdByHeader)
+	createdBy := strings.Replace(c.h.CreatedBy, "\t", " ", -1)
+	if c.trunc {
+		createdBy = stringutils.Truncate(createdBy, 45)
+	}
+	return createdBy
+}
+
+func (c *historyContext) Size() string {
+	c.addHeader(sizeHeader)
+	if !c.human {
+		return strconv.FormatInt(c.h.Size, 10)
+	}
+	return units.HumanSize(float64(c.h.Size))
+}
+
+func (c *historyContext) Comment() string {
+	c.addHeader(commentHeader)
+	return c.h.Comment
+}
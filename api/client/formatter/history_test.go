@@ -0,0 +1,129 @@
+package formatter
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/engine-api/types"
+)
+
+func TestHistoryContext(t *testing.T) {
+	imageID := stringid.GenerateRandomID()
+	unix := time.Now().Add(-65 * time.Second).Unix()
+	longCommand := "/bin/sh -c #(nop) ENV PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin"
+
+	var ctx historyContext
+	cases := []struct {
+		history   types.ImageHistory
+		trunc     bool
+		human     bool
+		expValue  string
+		expHeader string
+		call      func() string
+	}{
+		{types.ImageHistory{ID: imageID}, true, true, stringid.TruncateID(imageID), imageHeader, ctx.ID},
+		{types.ImageHistory{ID: imageID}, false, true, imageID, imageHeader, ctx.ID},
+		{types.ImageHistory{Created: unix}, true, true, "About a minute ago", createdSinceHeader, ctx.CreatedSince},
+		{types.ImageHistory{Created: unix}, true, false, time.Unix(unix, 0).Format(time.RFC3339), createdSinceHeader, ctx.CreatedSince},
+		{types.ImageHistory{Created: unix}, true, true, time.Unix(unix, 0).String(), createdAtHeader, ctx.CreatedAt},
+		{types.ImageHistory{CreatedBy: "/bin/sh -c\tls"}, true, true, "/bin/sh -c ls", createdByHeader, ctx.CreatedBy},
+		{types.ImageHistory{CreatedBy: longCommand}, true, true, longCommand[:45], createdByHeader, ctx.CreatedBy},
+		{types.ImageHistory{CreatedBy: longCommand}, false, true, longCommand, createdByHeader, ctx.CreatedBy},
+		{types.ImageHistory{Size: 10}, true, true, "10 B", sizeHeader, ctx.Size},
+		{types.ImageHistory{Size: 1234567}, true, false, strconv.Itoa(1234567), sizeHeader, ctx.Size},
+		{types.ImageHistory{Comment: "Imported from -"}, true, true, "Imported from -", commentHeader, ctx.Comment},
+	}
+
+	for _, c := range cases {
+		ctx = historyContext{h: c.history, trunc: c.trunc, human: c.human}
+		v := c.call()
+		if v != c.expValue {
+			t.Fatalf("Expected %s, was %s\n", c.expValue, v)
+		}
+
+		h := ctx.fullHeader()
+		if h != c.expHeader {
+			t.Fatalf("Expected %s, was %s\n", c.expHeader, h)
+		}
+	}
+}
+
+func TestHistoryContextWrite(t *testing.T) {
+	unixTime := time.Now().AddDate(0, 0, -1).Unix()
+
+	contexts := []struct {
+		context  HistoryContext
+		expected string
+	}{
+		// Table format
+		{
+			HistoryContext{
+				Context: Context{
+					Format: "table",
+				},
+				Human: true,
+			},
+			`IMAGE               CREATED             CREATED BY          SIZE                COMMENT
+imageID1            24 hours ago        /bin/bash ls        10 B                Hi
+imageID2            24 hours ago        /bin/bash echo      20 B                
+`,
+		},
+		{
+			HistoryContext{
+				Context: Context{
+					Format: "table",
+					Quiet:  true,
+				},
+			},
+			"imageID1\nimageID2\n",
+		},
+		{
+			HistoryContext{
+				Context: Context{
+					Format: "table {{.ID}}\t{{.Size}}",
+				},
+			},
+			`IMAGE               SIZE
+imageID1            10
+imageID2            20
+`,
+		},
+		// Raw Format
+		{
+			HistoryContext{
+				Context: Context{
+					Format: "raw",
+					Quiet:  true,
+				},
+			},
+			"image_id: imageID1\nimage_id: imageID2\n",
+		},
+		// Custom Format
+		{
+			HistoryContext{
+				Context: Context{
+					Format: "{{.ID}}: {{.CreatedBy}}",
+				},
+			},
+			"imageID1: /bin/bash ls\nimageID2: /bin/bash echo\n",
+		},
+	}
+
+	for _, context := range contexts {
+		history := []types.ImageHistory{
+			{ID: "imageID1", Created: unixTime, CreatedBy: "/bin/bash ls", Size: 10, Comment: "Hi"},
+			{ID: "imageID2", Created: unixTime, CreatedBy: "/bin/bash echo", Size: 20},
+		}
+		out := bytes.NewBufferString("")
+		context.context.Output = out
+		context.context.History = history
+		context.context.Write()
+		actual := out.String()
+		if actual != context.expected {
+			t.Fatalf("Expected \n%s, got \n%s", context.expected, actual)
+		}
+	}
+}
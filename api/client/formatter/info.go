@@ -0,0 +1,63 @@
+package formatter
+
+import (
+	"bytes"
+
+	"github.com/docker/engine-api/types"
+)
+
+// InfoContext contains the system-wide information required by the
+// formatter, encapsulate a Context struct. The information is not a list,
+// so it cannot be printed as a table. Custom formats are executed with all
+// the fields of the information, e.g. `{{.ServerVersion}}`.
+type InfoContext struct {
+	Context
+	// Info
+	Info types.Info
+}
+
+func (ctx InfoContext) Write() {
+	switch ctx.Format {
+	case jsonFormatKey:
+		ctx.writeJSON([]interface{}{ctx.Info})
+		return
+	case rawFormatKey:
+		ctx.Format = `id: {{.ID}}
+name: {{.Name}}
+server_version: {{.ServerVersion}}
+containers: {{.Containers}}
+containers_running: {{.ContainersRunning}}
+containers_paused: {{.ContainersPaused}}
+containers_stopped: {{.ContainersStopped}}
+images: {{.Images}}
+storage_driver: {{.Driver}}
+logging_driver: {{.LoggingDriver}}
+cgroup_driver: {{.CgroupDriver}}
+kernel_version: {{.KernelVersion}}
+operating_system: {{.OperatingSystem}}
+os_type: {{.OSType}}
+architecture: {{.Architecture}}
+cpus: {{.NCPU}}
+total_memory: {{.MemTotal}}
+docker_root_dir: {{.DockerRootDir}}
+`
+	}
+
+	ctx.buffer = bytes.NewBufferString("")
+	ctx.preformat()
+
+	tmpl, err := ctx.parseFormat()
+	if err != nil {
+		return
+	}
+
+	if err := ctx.contextFormat(tmpl, &infoContext{Info: ctx.Info}); err != nil {
+		return
+	}
+	ctx.buffer.WriteTo(ctx.Output)
+}
+
+type infoContext struct {
+	baseSubContext
+	types.Info
+}
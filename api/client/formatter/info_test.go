@@ -0,0 +1,82 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/docker/engine-api/types"
+)
+
+func TestInfoContextWrite(t *testing.T) {
+	info := types.Info{
+		ID:            "infoID",
+		Name:          "myhost",
+		ServerVersion: "1.12.0",
+		Containers:    3,
+		Images:        2,
+		Driver:        "overlay",
+	}
+
+	contexts := []struct {
+		context  InfoContext
+		expected string
+	}{
+		// Errors
+		{
+			InfoContext{
+				Context: Context{
+					Format: "{{InvalidFunction}}",
+				},
+			},
+			`Template parsing error: template: :1: function "InvalidFunction" not defined
+`,
+		},
+		// Custom Format
+		{
+			InfoContext{
+				Context: Context{
+					Format: "{{.ServerVersion}} {{.Driver}}",
+				},
+			},
+			"1.12.0 overlay\n",
+		},
+		{
+			InfoContext{
+				Context: Context{
+					Format: "{{.Containers}} containers, {{.Images}} images",
+				},
+			},
+			"3 containers, 2 images\n",
+		},
+	}
+
+	for _, context := range contexts {
+		out := bytes.NewBufferString("")
+		context.context.Output = out
+		context.context.Info = info
+		context.context.Write()
+		actual := out.String()
+		if actual != context.expected {
+			t.Fatalf("Expected \n%s, got \n%s", context.expected, actual)
+		}
+	}
+
+	out := bytes.NewBufferString("")
+	ctx := InfoContext{
+		Context: Context{
+			Format: "json",
+			Output: out,
+		},
+		Info: info,
+	}
+	ctx.Write()
+
+	var actual types.Info
+	if err := json.Unmarshal(out.Bytes(), &actual); err != nil {
+		t.Fatal(err)
+	}
+	if actual.ID != info.ID || actual.ServerVersion != info.ServerVersion {
+		t.Fatalf("Expected %v, got %v", info, actual)
+	}
+}
@@ -0,0 +1,128 @@
+package formatter
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/engine-api/types"
+)
+
+const (
+	defaultNetworkTableFormat = "table {{.ID}}\t{{.Name}}\t{{.Driver}}"
+
+	networkIDHeader = "NETWORK ID"
+	nameHeader      = "NAME"
+	scopeHeader     = "SCOPE"
+	ipv6Header      = "IPV6"
+	internalHeader  = "INTERNAL"
+)
+
+// NetworkContext contains network specific information required by the
+// formatter, encapsulate a Context struct.
+type NetworkContext struct {
+	Context
+	// Networks
+	Networks []types.NetworkResource
+}
+
+func (ctx NetworkContext) Write() {
+	switch ctx.Format {
+	case jsonFormatKey:
+		var elements []interface{}
+		for _, network := range ctx.Networks {
+			elements = append(elements, network)
+		}
+		ctx.writeJSON(elements)
+		return
+	case tableFormatKey:
+		if ctx.Quiet {
+			ctx.Format = defaultQuietFormat
+		} else {
+			ctx.Format = defaultNetworkTableFormat
+		}
+	case rawFormatKey:
+		if ctx.Quiet {
+			ctx.Format = `network_id: {{.ID}}`
+		} else {
+			ctx.Format = `network_id: {{.ID}}
+name: {{.Name}}
+driver: {{.Driver}}
+scope: {{.Scope}}
+`
+		}
+	}
+
+	ctx.buffer = bytes.NewBufferString("")
+	ctx.preformat()
+
+	tmpl, err := ctx.parseFormat()
+	if err != nil {
+		return
+	}
+
+	for _, network := range ctx.Networks {
+		networkCtx := &networkContext{
+			trunc: ctx.Trunc,
+			n:     network,
+		}
+		err = ctx.contextFormat(tmpl, networkCtx)
+		if err != nil {
+			return
+		}
+	}
+
+	ctx.postformat(tmpl, &networkContext{})
+}
+
+type networkContext struct {
+	baseSubContext
+	trunc bool
+	n     types.NetworkResource
+}
+
+func (c *networkContext) ID() string {
+	c.addHeader(networkIDHeader)
+	if c.trunc {
+		return stringid.TruncateID(c.n.ID)
+	}
+	return c.n.ID
+}
+
+func (c *networkContext) Name() string {
+	c.addHeader(nameHeader)
+	return c.n.Name
+}
+
+func (c *networkContext) Driver() string {
+	c.addHeader(driverHeader)
+	return c.n.Driver
+}
+
+func (c *networkContext) Scope() string {
+	c.addHeader(scopeHeader)
+	return c.n.Scope
+}
+
+func (c *networkContext) IPv6() string {
+	c.addHeader(ipv6Header)
+	return strconv.FormatBool(c.n.EnableIPv6)
+}
+
+func (c *networkContext) Internal() string {
+	c.addHeader(internalHeader)
+	return strconv.FormatBool(c.n.Internal)
+}
+
+func (c *networkContext) Labels() string {
+	c.addHeader(labelsHeader)
+	return joinLabels(c.n.Labels)
+}
+
+func (c *networkContext) Label(name string) string {
+	c.addHeader(labelHeader(name))
+	if c.n.Labels == nil {
+		return ""
+	}
+	return c.n.Labels[name]
+}
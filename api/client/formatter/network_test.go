@@ -0,0 +1,150 @@
+package formatter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/engine-api/types"
+)
+
+func TestNetworkContext(t *testing.T) {
+	networkID := stringid.GenerateRandomID()
+
+	var ctx networkContext
+	cases := []struct {
+		network   types.NetworkResource
+		trunc     bool
+		expValue  string
+		expHeader string
+		call      func() string
+	}{
+		{types.NetworkResource{ID: networkID}, true, stringid.TruncateID(networkID), networkIDHeader, ctx.ID},
+		{types.NetworkResource{ID: networkID}, false, networkID, networkIDHeader, ctx.ID},
+		{types.NetworkResource{Name: "network_name"}, true, "network_name", nameHeader, ctx.Name},
+		{types.NetworkResource{Driver: "driver_name"}, true, "driver_name", driverHeader, ctx.Driver},
+		{types.NetworkResource{Scope: "local"}, true, "local", scopeHeader, ctx.Scope},
+		{types.NetworkResource{EnableIPv6: true}, true, "true", ipv6Header, ctx.IPv6},
+		{types.NetworkResource{Internal: false}, true, "false", internalHeader, ctx.Internal},
+		{types.NetworkResource{}, true, "", labelsHeader, ctx.Labels},
+		{types.NetworkResource{Labels: map[string]string{"label1": "value1", "label2": "value2"}}, true, "label1=value1,label2=value2", labelsHeader, ctx.Labels},
+	}
+
+	for _, c := range cases {
+		ctx = networkContext{n: c.network, trunc: c.trunc}
+		v := c.call()
+		if strings.Contains(v, ",") {
+			compareMultipleValues(t, v, c.expValue)
+		} else if v != c.expValue {
+			t.Fatalf("Expected %s, was %s\n", c.expValue, v)
+		}
+
+		h := ctx.fullHeader()
+		if h != c.expHeader {
+			t.Fatalf("Expected %s, was %s\n", c.expHeader, h)
+		}
+	}
+}
+
+func TestNetworkContextWrite(t *testing.T) {
+	contexts := []struct {
+		context  NetworkContext
+		expected string
+	}{
+		// Errors
+		{
+			NetworkContext{
+				Context: Context{
+					Format: "{{InvalidFunction}}",
+				},
+			},
+			`Template parsing error: template: :1: function "InvalidFunction" not defined
+`,
+		},
+		// Table format
+		{
+			NetworkContext{
+				Context: Context{
+					Format: "table",
+				},
+			},
+			`NETWORK ID          NAME                DRIVER
+networkID1          foobar_baz          foo
+networkID2          foobar_bar          bar
+`,
+		},
+		{
+			NetworkContext{
+				Context: Context{
+					Format: "table",
+					Quiet:  true,
+				},
+			},
+			"networkID1\nnetworkID2\n",
+		},
+		{
+			NetworkContext{
+				Context: Context{
+					Format: "table {{.Name}}\t{{.Scope}}",
+				},
+			},
+			`NAME                SCOPE
+foobar_baz          local
+foobar_bar          global
+`,
+		},
+		// Raw Format
+		{
+			NetworkContext{
+				Context: Context{
+					Format: "raw",
+				},
+			},
+			`network_id: networkID1
+name: foobar_baz
+driver: foo
+scope: local
+
+network_id: networkID2
+name: foobar_bar
+driver: bar
+scope: global
+
+`,
+		},
+		{
+			NetworkContext{
+				Context: Context{
+					Format: "raw",
+					Quiet:  true,
+				},
+			},
+			"network_id: networkID1\nnetwork_id: networkID2\n",
+		},
+		// Custom Format
+		{
+			NetworkContext{
+				Context: Context{
+					Format: "{{.Name}}",
+				},
+			},
+			"foobar_baz\nfoobar_bar\n",
+		},
+	}
+
+	for _, context := range contexts {
+		networks := []types.NetworkResource{
+			{ID: "networkID1", Name: "foobar_baz", Driver: "foo", Scope: "local"},
+			{ID: "networkID2", Name: "foobar_bar", Driver: "bar", Scope: "global"},
+		}
+		out := bytes.NewBufferString("")
+		context.context.Output = out
+		context.context.Networks = networks
+		context.context.Write()
+		actual := out.String()
+		if actual != context.expected {
+			t.Fatalf("Expected \n%s, got \n%s", context.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package formatter
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/pkg/stringutils"
+	registrytypes "github.com/docker/engine-api/types/registry"
+)
+
+const (
+	defaultSearchTableFormat = "table {{.Name}}\t{{.Description}}\t{{.StarCount}}\t{{.IsOfficial}}\t{{.IsAutomated}}"
+
+	descriptionHeader = "DESCRIPTION"
+	starsHeader       = "STARS"
+	officialHeader    = "OFFICIAL"
+	automatedHeader   = "AUTOMATED"
+)
+
+// SearchContext contains search result specific information required by the
+// formatter, encapsulate a Context struct.
+type SearchContext struct {
+	Context
+	// Results
+	Results []registrytypes.SearchResult
+}
+
+func (ctx SearchContext) Write() {
+	switch ctx.Format {
+	case jsonFormatKey:
+		var elements []interface{}
+		for _, result := range ctx.Results {
+			elements = append(elements, result)
+		}
+		ctx.writeJSON(elements)
+		return
+	case tableFormatKey:
+		if ctx.Quiet {
+			ctx.Format = `{{.Name}}`
+		} else {
+			ctx.Format = defaultSearchTableFormat
+		}
+	case rawFormatKey:
+		if ctx.Quiet {
+			ctx.Format = `name: {{.Name}}`
+		} else {
+			ctx.Format = `name: {{.Name}}
+description: {{.Description}}
+star_count: {{.StarCount}}
+is_official: {{.IsOfficial}}
+is_automated: {{.IsAutomated}}
+`
+		}
+	}
+
+	ctx.buffer = bytes.NewBufferString("")
+	ctx.preformat()
+
+	tmpl, err := ctx.parseFormat()
+	if err != nil {
+		return
+	}
+
+	for _, result := range ctx.Results {
+		searchCtx := &searchContext{
+			trunc: ctx.Trunc,
+			s:     result,
+		}
+		err = ctx.contextFormat(tmpl, searchCtx)
+		if err != nil {
+			return
+		}
+	}
+
+	ctx.postformat(tmpl, &searchContext{})
+}
+
+type searchContext struct {
+	baseSubContext
+	trunc bool
+	s     registrytypes.SearchResult
+}
+
+func (c *searchContext) Name() string {
+	c.addHeader(nameHeader)
+	return c.s.Name
+}
+
+func (c *searchContext) Description() string {
+	c.addHeader(descriptionHeader)
+	desc := strings.Replace(c.s.Description, "\n", " ", -1)
+	desc = strings.Replace(desc, "\r", " ", -1)
+	if c.trunc && len(desc) > 45 {
+		desc = stringutils.Truncate(desc, 42) + "..."
+	}
+	return desc
+}
+
+func (c *searchContext) StarCount() string {
+	c.addHeader(starsHeader)
+	return strconv.Itoa(c.s.StarCount)
+}
+
+func (c *searchContext) IsOfficial() string {
+	c.addHeader(officialHeader)
+	return formatBool(c.s.IsOfficial)
+}
+
+func (c *searchContext) IsAutomated() string {
+	c.addHeader(automatedHeader)
+	return formatBool(c.s.IsAutomated || c.s.IsTrusted)
+}
+
+// formatBool returns "[OK]" for true, and an empty string otherwise.
+func formatBool(b bool) string {
+	if b {
+		return "[OK]"
+	}
+	return ""
+}
@@ -0,0 +1,120 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	registrytypes "github.com/docker/engine-api/types/registry"
+)
+
+func TestSearchContext(t *testing.T) {
+	longDescription := "Ubuntu is a Debian-based Linux operating system based on free software."
+
+	var ctx searchContext
+	cases := []struct {
+		result    registrytypes.SearchResult
+		trunc     bool
+		expValue  string
+		expHeader string
+		call      func() string
+	}{
+		{registrytypes.SearchResult{Name: "ubuntu"}, true, "ubuntu", nameHeader, ctx.Name},
+		{registrytypes.SearchResult{Description: "Official\nimage"}, true, "Official image", descriptionHeader, ctx.Description},
+		{registrytypes.SearchResult{Description: longDescription}, true, longDescription[:42] + "...", descriptionHeader, ctx.Description},
+		{registrytypes.SearchResult{Description: longDescription}, false, longDescription, descriptionHeader, ctx.Description},
+		{registrytypes.SearchResult{StarCount: 42}, true, "42", starsHeader, ctx.StarCount},
+		{registrytypes.SearchResult{IsOfficial: true}, true, "[OK]", officialHeader, ctx.IsOfficial},
+		{registrytypes.SearchResult{IsOfficial: false}, true, "", officialHeader, ctx.IsOfficial},
+		{registrytypes.SearchResult{IsAutomated: true}, true, "[OK]", automatedHeader, ctx.IsAutomated},
+		{registrytypes.SearchResult{IsTrusted: true}, true, "[OK]", automatedHeader, ctx.IsAutomated},
+	}
+
+	for _, c := range cases {
+		ctx = searchContext{s: c.result, trunc: c.trunc}
+		v := c.call()
+		if v != c.expValue {
+			t.Fatalf("Expected %s, was %s\n", c.expValue, v)
+		}
+
+		h := ctx.fullHeader()
+		if h != c.expHeader {
+			t.Fatalf("Expected %s, was %s\n", c.expHeader, h)
+		}
+	}
+}
+
+func TestSearchContextWrite(t *testing.T) {
+	contexts := []struct {
+		context  SearchContext
+		expected string
+	}{
+		// Table format
+		{
+			SearchContext{
+				Context: Context{
+					Format: "table",
+				},
+			},
+			`NAME                DESCRIPTION         STARS               OFFICIAL            AUTOMATED
+result1             Official build      5000                [OK]                
+result2             Not official        5                                       [OK]
+`,
+		},
+		{
+			SearchContext{
+				Context: Context{
+					Format: "table {{.Name}}\t{{.StarCount}}",
+				},
+			},
+			`NAME                STARS
+result1             5000
+result2             5
+`,
+		},
+		// Raw Format
+		{
+			SearchContext{
+				Context: Context{
+					Format: "raw",
+				},
+			},
+			`name: result1
+description: Official build
+star_count: 5000
+is_official: [OK]
+is_automated: 
+
+name: result2
+description: Not official
+star_count: 5
+is_official: 
+is_automated: [OK]
+
+`,
+		},
+		// Custom Format
+		{
+			SearchContext{
+				Context: Context{
+					Format: "{{.Name}}",
+				},
+			},
+			"result1\nresult2\n",
+		},
+	}
+
+	for _, context := range contexts {
+		results := []registrytypes.SearchResult{
+			{Name: "result1", Description: "Official build", StarCount: 5000, IsOfficial: true},
+			{Name: "result2", Description: "Not official", StarCount: 5, IsAutomated: true},
+		}
+		out := bytes.NewBufferString("")
+		context.context.Output = out
+		context.context.Results = results
+		context.context.Write()
+		actual := out.String()
+		if actual != context.expected {
+			t.Fatalf("Expected \n%s, got \n%s", context.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package formatter
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/docker/go-units"
+)
+
+const (
+	defaultStatsTableFormat = "table {{.Container}}\t{{.CPUPerc}}\t{{.MemUsage}}\t{{.MemPerc}}\t{{.NetIO}}\t{{.BlockIO}}\t{{.PIDs}}"
+
+	containerHeader = "CONTAINER"
+	cpuPercHeader   = "CPU %"
+	memUsageHeader  = "MEM USAGE / LIMIT"
+	memPercHeader   = "MEM %"
+	netIOHeader     = "NET I/O"
+	blockIOHeader   = "BLOCK I/O"
+	pidsHeader      = "PIDS"
+
+	// invalidStat is displayed instead of the statistics of containers for
+	// which they could not be collected.
+	invalidStat = "--"
+)
+
+// StatsEntry represents the statistics collected for a container.
+type StatsEntry struct {
+	Name             string
+	CPUPercentage    float64
+	Memory           float64
+	MemoryLimit      float64
+	MemoryPercentage float64
+	NetworkRx        float64
+	NetworkTx        float64
+	BlockRead        float64
+	BlockWrite       float64
+	PidsCurrent      uint64
+	// IsInvalid is set when the statistics could not be collected.
+	IsInvalid bool
+}
+
+// StatsContext contains container statistics specific information required
+// by the formatter, encapsulate a Context struct.
+type StatsContext struct {
+	Context
+	// Stats
+	Stats []StatsEntry
+}
+
+func (ctx StatsContext) Write() {
+	switch ctx.Format {
+	case jsonFormatKey:
+		var elements []interface{}
+		for _, entry := range ctx.Stats {
+			elements = append(elements, entry)
+		}
+		ctx.writeJSON(elements)
+		return
+	case tableFormatKey:
+		ctx.Format = defaultStatsTableFormat
+	case rawFormatKey:
+		ctx.Format = `container: {{.Container}}
+cpu_percentage: {{.CPUPerc}}
+memory_usage: {{.MemUsage}}
+memory_percentage: {{.MemPerc}}
+network_io: {{.NetIO}}
+block_io: {{.BlockIO}}
+pids: {{.PIDs}}
+`
+	}
+
+	ctx.buffer = bytes.NewBufferString("")
+	ctx.preformat()
+
+	tmpl, err := ctx.parseFormat()
+	if err != nil {
+		return
+	}
+
+	for _, entry := range ctx.Stats {
+		statsCtx := &statsContext{
+			s: entry,
+		}
+		err = ctx.contextFormat(tmpl, statsCtx)
+		if err != nil {
+			return
+		}
+	}
+
+	ctx.postformat(tmpl, &statsContext{})
+}
+
+type statsContext struct {
+	baseSubContext
+	s StatsEntry
+}
+
+func (c *statsContext) Container() string {
+	c.addHeader(containerHeader)
+	return c.s.Name
+}
+
+func (c *statsContext) CPUPerc() string {
+	c.addHeader(cpuPercHeader)
+	if c.s.IsInvalid {
+		return invalidStat
+	}
+	return fmt.Sprintf("%.2f%%", c.s.CPUPercentage)
+}
+
+func (c *statsContext) MemUsage() string {
+	c.addHeader(memUsageHeader)
+	if c.s.IsInvalid {
+		return fmt.Sprintf("%s / %s", invalidStat, invalidStat)
+	}
+	return fmt.Sprintf("%s / %s", units.BytesSize(c.s.Memory), units.BytesSize(c.s.MemoryLimit))
+}
+
+func (c *statsContext) MemPerc() string {
+	c.addHeader(memPercHeader)
+	if c.s.IsInvalid {
+		return invalidStat
+	}
+	return fmt.Sprintf("%.2f%%", c.s.MemoryPercentage)
+}
+
+func (c *statsContext) NetIO() string {
+	c.addHeader(netIOHeader)
+	if c.s.IsInvalid {
+		return fmt.Sprintf("%s / %s", invalidStat, invalidStat)
+	}
+	return fmt.Sprintf("%s / %s", units.HumanSize(c.s.NetworkRx), units.HumanSize(c.s.NetworkTx))
+}
+
+func (c *statsContext) BlockIO() string {
+	c.addHeader(blockIOHeader)
+	if c.s.IsInvalid {
+		return fmt.Sprintf("%s / %s", invalidStat, invalidStat)
+	}
+	return fmt.Sprintf("%s / %s", units.HumanSize(c.s.BlockRead), units.HumanSize(c.s.BlockWrite))
+}
+
+func (c *statsContext) PIDs() string {
+	c.addHeader(pidsHeader)
+	if c.s.IsInvalid {
+		return invalidStat
+	}
+	return fmt.Sprintf("%d", c.s.PidsCurrent)
+}
@@ -0,0 +1,85 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestStatsContextWrite(t *testing.T) {
+	contexts := []struct {
+		context  StatsContext
+		expected string
+	}{
+		// Errors
+		{
+			StatsContext{
+				Context: Context{
+					Format: "{{InvalidFunction}}",
+				},
+			},
+			`Template parsing error: template: :1: function "InvalidFunction" not defined
+`,
+		},
+		// Table format
+		{
+			StatsContext{
+				Context: Context{
+					Format: "table",
+				},
+			},
+			`CONTAINER           CPU %               MEM USAGE / LIMIT   MEM %               NET I/O               BLOCK I/O             PIDS
+app                 30.00%              100 MiB / 2 GiB     4.88%               104.9 MB / 838.9 MB   104.9 MB / 838.9 MB   1
+broken              --                  -- / --             --                  -- / --               -- / --               --
+`,
+		},
+		{
+			StatsContext{
+				Context: Context{
+					Format: "table {{.Container}}\t{{.CPUPerc}}",
+				},
+			},
+			`CONTAINER           CPU %
+app                 30.00%
+broken              --
+`,
+		},
+		// Custom Format
+		{
+			StatsContext{
+				Context: Context{
+					Format: "{{.Container}}: {{.MemUsage}}",
+				},
+			},
+			"app: 100 MiB / 2 GiB\nbroken: -- / --\n",
+		},
+	}
+
+	for _, context := range contexts {
+		stats := []StatsEntry{
+			{
+				Name:             "app",
+				CPUPercentage:    30.0,
+				Memory:           100 * 1024 * 1024.0,
+				MemoryLimit:      2048 * 1024 * 1024.0,
+				MemoryPercentage: 100.0 / 2048.0 * 100.0,
+				NetworkRx:        100 * 1024 * 1024,
+				NetworkTx:        800 * 1024 * 1024,
+				BlockRead:        100 * 1024 * 1024,
+				BlockWrite:       800 * 1024 * 1024,
+				PidsCurrent:      1,
+			},
+			{
+				Name:      "broken",
+				IsInvalid: true,
+			},
+		}
+		out := bytes.NewBufferString("")
+		context.context.Output = out
+		context.context.Stats = stats
+		context.context.Write()
+		actual := out.String()
+		if actual != context.expected {
+			t.Fatalf("Expected \n%s, got \n%s", context.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package formatter
+
+import (
+	"bytes"
+
+	"github.com/docker/engine-api/types"
+)
+
+const (
+	defaultVolumeQuietFormat = "{{.Name}}"
+	defaultVolumeTableFormat = "table {{.Driver}}\t{{.Name}}"
+
+	volumeNameHeader = "VOLUME NAME"
+	driverHeader     = "DRIVER"
+	mountpointHeader = "MOUNTPOINT"
+)
+
+// VolumeContext contains volume specific information required by the formatter,
+// encapsulate a Context struct.
+type VolumeContext struct {
+	Context
+	// Volumes
+	Volumes []*types.Volume
+}
+
+func (ctx VolumeContext) Write() {
+	switch ctx.Format {
+	case jsonFormatKey:
+		var elements []interface{}
+		for _, volume := range ctx.Volumes {
+			elements = append(elements, volume)
+		}
+		ctx.writeJSON(elements)
+		return
+	case tableFormatKey:
+		if ctx.Quiet {
+			ctx.Format = defaultVolumeQuietFormat
+		} else {
+			ctx.Format = defaultVolumeTableFormat
+		}
+	case rawFormatKey:
+		if ctx.Quiet {
+			ctx.Format = `name: {{.Name}}`
+		} else {
+			ctx.Format = `name: {{.Name}}
+driver: {{.Driver}}
+mountpoint: {{.Mountpoint}}
+labels: {{.Labels}}
+`
+		}
+	}
+
+	ctx.buffer = bytes.NewBufferString("")
+	ctx.preformat()
+
+	tmpl, err := ctx.parseFormat()
+	if err != nil {
+		return
+	}
+
+	for _, volume := range ctx.Volumes {
+		volumeCtx := &volumeContext{
+			v: volume,
+		}
+		err = ctx.contextFormat(tmpl, volumeCtx)
+		if err != nil {
+			return
+		}
+	}
+
+	ctx.postformat(tmpl, &volumeContext{v: &types.Volume{}})
+}
+
+type volumeContext struct {
+	baseSubContext
+	v *types.Volume
+}
+
+func (c *volumeContext) Name() string {
+	c.addHeader(volumeNameHeader)
+	return c.v.Name
+}
+
+func (c *volumeContext) Driver() string {
+	c.addHeader(driverHeader)
+	return c.v.Driver
+}
+
+func (c *volumeContext) Mountpoint() string {
+	c.addHeader(mountpointHeader)
+	return c.v.Mountpoint
+}
+
+func (c *volumeContext) Labels() string {
+	c.addHeader(labelsHeader)
+	return joinLabels(c.v.Labels)
+}
+
+func (c *volumeContext) Label(name string) string {
+	c.addHeader(labelHeader(name))
+	if c.v.Labels == nil {
+		return ""
+	}
+	return c.v.Labels[name]
+}
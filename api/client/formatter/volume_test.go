@@ -0,0 +1,181 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/docker/engine-api/types"
+)
+
+func TestVolumeContext(t *testing.T) {
+	var ctx volumeContext
+	cases := []struct {
+		volume    *types.Volume
+		expValue  string
+		expHeader string
+		call      func() string
+	}{
+		{&types.Volume{Name: "myvolume"}, "myvolume", volumeNameHeader, ctx.Name},
+		{&types.Volume{Driver: "local"}, "local", driverHeader, ctx.Driver},
+		{&types.Volume{Mountpoint: "/var/lib/docker/volumes/myvolume/_data"}, "/var/lib/docker/volumes/myvolume/_data", mountpointHeader, ctx.Mountpoint},
+		{&types.Volume{}, "", labelsHeader, ctx.Labels},
+		{&types.Volume{Labels: map[string]string{"cpu": "6", "storage": "ssd"}}, "cpu=6,storage=ssd", labelsHeader, ctx.Labels},
+	}
+
+	for _, c := range cases {
+		ctx = volumeContext{v: c.volume}
+		v := c.call()
+		if strings.Contains(v, ",") {
+			compareMultipleValues(t, v, c.expValue)
+		} else if v != c.expValue {
+			t.Fatalf("Expected %s, was %s\n", c.expValue, v)
+		}
+
+		h := ctx.fullHeader()
+		if h != c.expHeader {
+			t.Fatalf("Expected %s, was %s\n", c.expHeader, h)
+		}
+	}
+
+	ctx = volumeContext{v: &types.Volume{Labels: map[string]string{"com.example.tier": "gold"}}}
+	if v := ctx.Label("com.example.tier"); v != "gold" {
+		t.Fatalf("Expected gold, was %s\n", v)
+	}
+	if h := ctx.fullHeader(); h != "TIER" {
+		t.Fatalf("Expected TIER, was %s\n", h)
+	}
+}
+
+func TestVolumeContextWrite(t *testing.T) {
+	contexts := []struct {
+		context  VolumeContext
+		expected string
+	}{
+		// Errors
+		{
+			VolumeContext{
+				Context: Context{
+					Format: "{{InvalidFunction}}",
+				},
+			},
+			`Template parsing error: template: :1: function "InvalidFunction" not defined
+`,
+		},
+		// Table format
+		{
+			VolumeContext{
+				Context: Context{
+					Format: "table",
+				},
+			},
+			`DRIVER              VOLUME NAME
+local               foobar_baz
+foo                 foobar_bar
+`,
+		},
+		{
+			VolumeContext{
+				Context: Context{
+					Format: "table",
+					Quiet:  true,
+				},
+			},
+			"foobar_baz\nfoobar_bar\n",
+		},
+		{
+			VolumeContext{
+				Context: Context{
+					Format: "table {{.Name}}\t{{.Mountpoint}}",
+				},
+			},
+			`VOLUME NAME         MOUNTPOINT
+foobar_baz          /baz
+foobar_bar          /bar
+`,
+		},
+		// Raw Format
+		{
+			VolumeContext{
+				Context: Context{
+					Format: "raw",
+				},
+			},
+			`name: foobar_baz
+driver: local
+mountpoint: /baz
+labels: 
+
+name: foobar_bar
+driver: foo
+mountpoint: /bar
+labels: 
+
+`,
+		},
+		{
+			VolumeContext{
+				Context: Context{
+					Format: "raw",
+					Quiet:  true,
+				},
+			},
+			"name: foobar_baz\nname: foobar_bar\n",
+		},
+		// Custom Format
+		{
+			VolumeContext{
+				Context: Context{
+					Format: "{{.Name}}",
+				},
+			},
+			"foobar_baz\nfoobar_bar\n",
+		},
+	}
+
+	for _, context := range contexts {
+		volumes := []*types.Volume{
+			{Name: "foobar_baz", Driver: "local", Mountpoint: "/baz"},
+			{Name: "foobar_bar", Driver: "foo", Mountpoint: "/bar"},
+		}
+		out := bytes.NewBufferString("")
+		context.context.Output = out
+		context.context.Volumes = volumes
+		context.context.Write()
+		actual := out.String()
+		if actual != context.expected {
+			t.Fatalf("Expected \n%s, got \n%s", context.expected, actual)
+		}
+	}
+}
+
+func TestVolumeContextWriteJSON(t *testing.T) {
+	volumes := []*types.Volume{
+		{Name: "foobar_baz", Driver: "local"},
+		{Name: "foobar_bar", Driver: "foo"},
+	}
+	out := bytes.NewBufferString("")
+	ctx := VolumeContext{
+		Context: Context{
+			Format: "json",
+			Output: out,
+		},
+		Volumes: volumes,
+	}
+	ctx.Write()
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != len(volumes) {
+		t.Fatalf("Expected %d lines, got %q", len(volumes), out.String())
+	}
+	for i, line := range lines {
+		var v types.Volume
+		if err := json.Unmarshal([]byte(line), &v); err != nil {
+			t.Fatal(err)
+		}
+		if v.Name != volumes[i].Name || v.Driver != volumes[i].Driver {
+			t.Fatalf("Expected %v, got %v", volumes[i], v)
+		}
+	}
+}
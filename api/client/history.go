@@ -1,19 +1,11 @@
 package client
 
 import (
-	"fmt"
-	"strconv"
-	"strings"
-	"text/tabwriter"
-	"time"
-
 	"golang.org/x/net/context"
 
+	"github.com/docker/docker/api/client/formatter"
 	Cli "github.com/docker/docker/cli"
 	flag "github.com/docker/docker/pkg/mflag"
-	"github.com/docker/docker/pkg/stringid"
-	"github.com/docker/docker/pkg/stringutils"
-	"github.com/docker/go-units"
 )
 
 // CmdHistory shows the history of an image.
@@ -24,6 +16,7 @@ func (cli *DockerCli) CmdHistory(args ...string) error {
 	human := cmd.Bool([]string{"H", "-human"}, true, "Print sizes and dates in human readable format")
 	quiet := cmd.Bool([]string{"q", "-quiet"}, false, "Only show numeric IDs")
 	noTrunc := cmd.Bool([]string{"-no-trunc"}, false, "Don't truncate output")
+	format := cmd.String([]string{"-format"}, "", "Pretty-print the history using a Go template")
 	cmd.Require(flag.Exact, 1)
 
 	cmd.ParseFlags(args, true)
@@ -33,44 +26,27 @@ func (cli *DockerCli) CmdHistory(args ...string) error {
 		return err
 	}
 
-	w := tabwriter.NewWriter(cli.out, 20, 1, 3, ' ', 0)
-
-	if *quiet {
-		for _, entry := range history {
-			if *noTrunc {
-				fmt.Fprintf(w, "%s\n", entry.ID)
-			} else {
-				fmt.Fprintf(w, "%s\n", stringid.TruncateID(entry.ID))
-			}
+	f := *format
+	if len(f) == 0 {
+		if len(cli.HistoryFormat()) > 0 && !*quiet {
+			f = cli.HistoryFormat()
+		} else {
+			f = "table"
 		}
-		w.Flush()
-		return nil
 	}
 
-	var imageID string
-	var createdBy string
-	var created string
-	var size string
-
-	fmt.Fprintln(w, "IMAGE\tCREATED\tCREATED BY\tSIZE\tCOMMENT")
-	for _, entry := range history {
-		imageID = entry.ID
-		createdBy = strings.Replace(entry.CreatedBy, "\t", " ", -1)
-		if *noTrunc == false {
-			createdBy = stringutils.Truncate(createdBy, 45)
-			imageID = stringid.TruncateID(entry.ID)
-		}
+	historyCtx := formatter.HistoryContext{
+		Context: formatter.Context{
+			Output: cli.out,
+			Format: f,
+			Quiet:  *quiet,
+			Trunc:  !*noTrunc,
+		},
+		Human:   *human,
+		History: history,
+	}
 
-		if *human {
-			created = units.HumanDuration(time.Now().UTC().Sub(time.Unix(entry.Created, 0))) + " ago"
-			size = units.HumanSize(float64(entry.Size))
-		} else {
-			created = time.Unix(entry.Created, 0).Format(time.RFC3339)
-			size = strconv.FormatInt(entry.Size, 10)
-		}
+	historyCtx.Write()
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", imageID, created, createdBy, size, entry.Comment)
-	}
-	w.Flush()
 	return nil
 }
@@ -2,10 +2,12 @@ package client
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"golang.org/x/net/context"
 
+	"github.com/docker/docker/api/client/formatter"
 	Cli "github.com/docker/docker/cli"
 	"github.com/docker/docker/pkg/ioutils"
 	flag "github.com/docker/docker/pkg/mflag"
@@ -18,15 +20,36 @@ import (
 // Usage: docker info
 func (cli *DockerCli) CmdInfo(args ...string) error {
 	cmd := Cli.Subcmd("info", nil, Cli.DockerCommands["info"].Description, true)
+	format := cmd.String([]string{"f", "-format"}, "", "Format the output using the given go template")
 	cmd.Require(flag.Exact, 0)
 
 	cmd.ParseFlags(args, true)
 
+	f := *format
+	if len(f) == 0 {
+		f = cli.InfoFormat()
+	}
+	if strings.HasPrefix(f, "table") {
+		return fmt.Errorf("the table format is not supported by info")
+	}
+
 	info, err := cli.client.Info(context.Background())
 	if err != nil {
 		return err
 	}
 
+	if len(f) > 0 {
+		infoCtx := formatter.InfoContext{
+			Context: formatter.Context{
+				Output: cli.out,
+				Format: f,
+			},
+			Info: info,
+		}
+		infoCtx.Write()
+		return nil
+	}
+
 	fmt.Fprintf(cli.out, "Containers: %d\n", info.Containers)
 	fmt.Fprintf(cli.out, " Running: %d\n", info.ContainersRunning)
 	fmt.Fprintf(cli.out, " Paused: %d\n", info.ContainersPaused)
@@ -68,6 +91,16 @@ func (cli *DockerCli) CmdInfo(args ...string) error {
 		fmt.Fprintf(cli.out, "\n")
 	}
 
+	if len(info.Runtimes) > 0 {
+		var runtimes []string
+		for name := range info.Runtimes {
+			runtimes = append(runtimes, name)
+		}
+		sort.Strings(runtimes)
+		fmt.Fprintf(cli.out, "Runtimes: %s\n", strings.Join(runtimes, " "))
+		fmt.Fprintf(cli.out, "Default Runtime: %s\n", info.DefaultRuntime)
+	}
+
 	ioutils.FprintfIfNotEmpty(cli.out, "Kernel Version: %s\n", info.KernelVersion)
 	ioutils.FprintfIfNotEmpty(cli.out, "Operating System: %s\n", info.OperatingSystem)
 	ioutils.FprintfIfNotEmpty(cli.out, "OSType: %s\n", info.OSType)
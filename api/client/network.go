@@ -5,14 +5,13 @@ import (
 	"net"
 	"sort"
 	"strings"
-	"text/tabwriter"
 
 	"golang.org/x/net/context"
 
+	"github.com/docker/docker/api/client/formatter"
 	Cli "github.com/docker/docker/cli"
 	"github.com/docker/docker/opts"
 	flag "github.com/docker/docker/pkg/mflag"
-	"github.com/docker/docker/pkg/stringid"
 	runconfigopts "github.com/docker/docker/runconfig/opts"
 	"github.com/docker/engine-api/types"
 	"github.com/docker/engine-api/types/filters"
@@ -165,6 +164,7 @@ func (cli *DockerCli) CmdNetworkLs(args ...string) error {
 	cmd := Cli.Subcmd("network ls", nil, "Lists networks", true)
 	quiet := cmd.Bool([]string{"q", "-quiet"}, false, "Only display numeric IDs")
 	noTrunc := cmd.Bool([]string{"-no-trunc"}, false, "Do not truncate the output")
+	format := cmd.String([]string{"-format"}, "", "Pretty-print networks using a Go template")
 
 	flFilter := opts.NewListOpts(nil)
 	cmd.Var(&flFilter, []string{"f", "-filter"}, "Filter output based on conditions provided")
@@ -193,31 +193,29 @@ func (cli *DockerCli) CmdNetworkLs(args ...string) error {
 		return err
 	}
 
-	wr := tabwriter.NewWriter(cli.out, 20, 1, 3, ' ', 0)
-
-	// unless quiet (-q) is specified, print field titles
-	if !*quiet {
-		fmt.Fprintln(wr, "NETWORK ID\tNAME\tDRIVER")
+	f := *format
+	if len(f) == 0 {
+		if len(cli.NetworksFormat()) > 0 && !*quiet {
+			f = cli.NetworksFormat()
+		} else {
+			f = "table"
+		}
 	}
+
 	sort.Sort(byNetworkName(networkResources))
-	for _, networkResource := range networkResources {
-		ID := networkResource.ID
-		netName := networkResource.Name
-		if !*noTrunc {
-			ID = stringid.TruncateID(ID)
-		}
-		if *quiet {
-			fmt.Fprintln(wr, ID)
-			continue
-		}
-		driver := networkResource.Driver
-		fmt.Fprintf(wr, "%s\t%s\t%s\t",
-			ID,
-			netName,
-			driver)
-		fmt.Fprint(wr, "\n")
+
+	networksCtx := formatter.NetworkContext{
+		Context: formatter.Context{
+			Output: cli.out,
+			Format: f,
+			Quiet:  *quiet,
+			Trunc:  !*noTrunc,
+		},
+		Networks: networkResources,
 	}
-	wr.Flush()
+
+	networksCtx.Write()
+
 	return nil
 }
 
@@ -20,9 +20,15 @@ func (cli *DockerCli) CmdRestart(args ...string) error {
 
 	cmd.ParseFlags(args, true)
 
+	// Use the stop timeout of the container unless the flag is given
+	var timeout *int
+	if cmd.IsSet("t") || cmd.IsSet("-time") {
+		timeout = nSeconds
+	}
+
 	var errs []string
 	for _, name := range cmd.Args() {
-		if err := cli.client.ContainerRestart(context.Background(), name, *nSeconds); err != nil {
+		if err := cli.client.ContainerRestart(context.Background(), name, timeout); err != nil {
 			errs = append(errs, err.Error())
 		} else {
 			fmt.Fprintf(cli.out, "%s\n", name)
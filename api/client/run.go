@@ -1,11 +1,13 @@
 package client
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http/httputil"
 	"os"
 	"runtime"
+	"strconv"
 	"strings"
 
 	"golang.org/x/net/context"
@@ -17,6 +19,8 @@ import (
 	"github.com/docker/docker/pkg/signal"
 	runconfigopts "github.com/docker/docker/runconfig/opts"
 	"github.com/docker/engine-api/types"
+	"github.com/docker/engine-api/types/events"
+	"github.com/docker/engine-api/types/filters"
 	"github.com/docker/libnetwork/resolvconf/dns"
 )
 
@@ -25,6 +29,10 @@ const (
 	errCmdCouldNotBeInvoked = "could not be invoked"
 )
 
+// errContainerRemoved stops reading the events of a container once it has
+// been removed.
+var errContainerRemoved = errors.New("container removed")
+
 func (cid *cidFile) Close() error {
 	cid.file.Close()
 
@@ -80,7 +88,6 @@ func (cli *DockerCli) CmdRun(args ...string) error {
 
 		ErrConflictAttachDetach               = fmt.Errorf("Conflicting options: -a and -d")
 		ErrConflictRestartPolicyAndAutoRemove = fmt.Errorf("Conflicting options: --restart and --rm")
-		ErrConflictDetachAutoRemove           = fmt.Errorf("Conflicting options: --rm and -d")
 	)
 
 	config, hostConfig, networkingConfig, cmd, err := runconfigopts.Parse(cmd, args)
@@ -112,6 +119,7 @@ func (cli *DockerCli) CmdRun(args ...string) error {
 	}
 
 	config.ArgsEscaped = false
+	hostConfig.AutoRemove = *flAutoRemove
 
 	if !*flDetach {
 		if err := cli.CheckTtyInput(config.AttachStdin, config.Tty); err != nil {
@@ -124,10 +132,6 @@ func (cli *DockerCli) CmdRun(args ...string) error {
 				return ErrConflictAttachDetach
 			}
 		}
-		if *flAutoRemove {
-			return ErrConflictDetachAutoRemove
-		}
-
 		config.AttachStdin = false
 		config.AttachStdout = false
 		config.AttachStderr = false
@@ -140,6 +144,10 @@ func (cli *DockerCli) CmdRun(args ...string) error {
 		sigProxy = false
 	}
 
+	if *flAutoRemove && (hostConfig.RestartPolicy.IsAlways() || hostConfig.RestartPolicy.IsOnFailure() || hostConfig.RestartPolicy.IsUnlessStopped()) {
+		return ErrConflictRestartPolicyAndAutoRemove
+	}
+
 	// Telling the Windows daemon the initial size of the tty during start makes
 	// a far better user experience rather than relying on subsequent resizes
 	// to cause things to catch up.
@@ -170,9 +178,6 @@ func (cli *DockerCli) CmdRun(args ...string) error {
 			fmt.Fprintf(cli.out, "%s\n", createResponse.ID)
 		}()
 	}
-	if *flAutoRemove && (hostConfig.RestartPolicy.IsAlways() || hostConfig.RestartPolicy.IsOnFailure()) {
-		return ErrConflictRestartPolicyAndAutoRemove
-	}
 	attach := config.AttachStdin || config.AttachStdout || config.AttachStderr
 	if attach {
 		var (
@@ -222,12 +227,14 @@ func (cli *DockerCli) CmdRun(args ...string) error {
 		})
 	}
 
-	if *flAutoRemove {
-		defer func() {
-			if err := cli.removeContainer(createResponse.ID, true, false, true); err != nil {
-				fmt.Fprintf(cli.err, "%v\n", err)
-			}
-		}()
+	// The daemon removes the container as soon as it exits, subscribe to its
+	// events before starting it to get the exit code.
+	var statusChan <-chan int
+	if *flAutoRemove && (config.AttachStdout || config.AttachStderr) {
+		statusChan, err = cli.waitExitOrRemoved(createResponse.ID)
+		if err != nil {
+			return runStartContainerErr(err)
+		}
 	}
 
 	//start the container
@@ -268,14 +275,9 @@ func (cli *DockerCli) CmdRun(args ...string) error {
 
 	// Attached mode
 	if *flAutoRemove {
-		// Autoremove: wait for the container to finish, retrieve
-		// the exit code and remove the container
-		if status, err = cli.client.ContainerWait(context.Background(), createResponse.ID); err != nil {
-			return runStartContainerErr(err)
-		}
-		if _, status, err = getExitCode(cli, createResponse.ID); err != nil {
-			return err
-		}
+		// Autoremove: wait for the daemon to remove the container, and
+		// retrieve the exit code it reported when the container died
+		status = <-statusChan
 	} else {
 		// No Autoremove: Simply retrieve the exit code
 		if !config.Tty {
@@ -296,3 +298,46 @@ func (cli *DockerCli) CmdRun(args ...string) error {
 	}
 	return nil
 }
+
+// waitExitOrRemoved waits for a container created with AutoRemove to be
+// removed by the daemon, and returns the exit code of the container on the
+// channel. It returns 125 if the events of the container could not be read.
+func (cli *DockerCli) waitExitOrRemoved(containerID string) (<-chan int, error) {
+	f := filters.NewArgs()
+	f.Add("type", "container")
+	f.Add("container", containerID)
+	options := types.EventsOptions{
+		Filters: f,
+	}
+	resBody, err := cli.client.Events(context.Background(), options)
+	if err != nil {
+		return nil, err
+	}
+
+	statusChan := make(chan int, 1)
+	go func() {
+		defer resBody.Close()
+
+		status := 125
+		err := decodeEvents(resBody, func(event events.Message, err error) error {
+			if err != nil {
+				return err
+			}
+			switch event.Action {
+			case "die":
+				if s, err := strconv.Atoi(event.Actor.Attributes["exitCode"]); err == nil {
+					status = s
+				}
+			case "destroy":
+				return errContainerRemoved
+			}
+			return nil
+		})
+		if err != nil && err != errContainerRemoved {
+			logrus.Errorf("Error waiting for container %s: %v", containerID, err)
+			status = 125
+		}
+		statusChan <- status
+	}()
+	return statusChan, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	Cli "github.com/docker/docker/cli"
 	flag "github.com/docker/docker/pkg/mflag"
+	"github.com/docker/engine-api/types"
 )
 
 // CmdSave saves one or more images to a tar archive.
@@ -18,6 +19,7 @@ import (
 func (cli *DockerCli) CmdSave(args ...string) error {
 	cmd := Cli.Subcmd("save", []string{"IMAGE [IMAGE...]"}, Cli.DockerCommands["save"].Description+" (streamed to STDOUT by default)", true)
 	outfile := cmd.String([]string{"o", "-output"}, "", "Write to a file, instead of STDOUT")
+	format := cmd.String([]string{"-format"}, "docker", "Format of the archive (docker or oci)")
 	cmd.Require(flag.Min, 1)
 
 	cmd.ParseFlags(args, true)
@@ -26,7 +28,11 @@ func (cli *DockerCli) CmdSave(args ...string) error {
 		return errors.New("Cowardly refusing to save to a terminal. Use the -o flag or redirect.")
 	}
 
-	responseBody, err := cli.client.ImageSave(context.Background(), cmd.Args())
+	options := types.ImageSaveOptions{
+		Format: *format,
+	}
+
+	responseBody, err := cli.client.ImageSave(context.Background(), cmd.Args(), options)
 	if err != nil {
 		return err
 	}
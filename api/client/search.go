@@ -1,17 +1,14 @@
 package client
 
 import (
-	"fmt"
 	"net/url"
 	"sort"
-	"strings"
-	"text/tabwriter"
 
 	"golang.org/x/net/context"
 
+	"github.com/docker/docker/api/client/formatter"
 	Cli "github.com/docker/docker/cli"
 	flag "github.com/docker/docker/pkg/mflag"
-	"github.com/docker/docker/pkg/stringutils"
 	"github.com/docker/docker/registry"
 	"github.com/docker/engine-api/types"
 	registrytypes "github.com/docker/engine-api/types/registry"
@@ -25,6 +22,7 @@ func (cli *DockerCli) CmdSearch(args ...string) error {
 	noTrunc := cmd.Bool([]string{"-no-trunc"}, false, "Don't truncate output")
 	automated := cmd.Bool([]string{"-automated"}, false, "Only show automated builds")
 	stars := cmd.Uint([]string{"s", "-stars"}, 0, "Only displays with at least x stars")
+	format := cmd.String([]string{"-format"}, "", "Pretty-print search results using a Go template")
 	cmd.Require(flag.Exact, 1)
 
 	cmd.ParseFlags(args, true)
@@ -59,29 +57,34 @@ func (cli *DockerCli) CmdSearch(args ...string) error {
 	results := searchResultsByStars(unorderedResults)
 	sort.Sort(results)
 
-	w := tabwriter.NewWriter(cli.out, 10, 1, 3, ' ', 0)
-	fmt.Fprintf(w, "NAME\tDESCRIPTION\tSTARS\tOFFICIAL\tAUTOMATED\n")
+	var filtered []registrytypes.SearchResult
 	for _, res := range results {
 		if (*automated && !res.IsAutomated) || (int(*stars) > res.StarCount) {
 			continue
 		}
-		desc := strings.Replace(res.Description, "\n", " ", -1)
-		desc = strings.Replace(desc, "\r", " ", -1)
-		if !*noTrunc && len(desc) > 45 {
-			desc = stringutils.Truncate(desc, 42) + "..."
-		}
-		fmt.Fprintf(w, "%s\t%s\t%d\t", res.Name, desc, res.StarCount)
-		if res.IsOfficial {
-			fmt.Fprint(w, "[OK]")
+		filtered = append(filtered, res)
+	}
 
+	f := *format
+	if len(f) == 0 {
+		if len(cli.SearchFormat()) > 0 {
+			f = cli.SearchFormat()
+		} else {
+			f = "table"
 		}
-		fmt.Fprint(w, "\t")
-		if res.IsAutomated || res.IsTrusted {
-			fmt.Fprint(w, "[OK]")
-		}
-		fmt.Fprint(w, "\n")
 	}
-	w.Flush()
+
+	searchCtx := formatter.SearchContext{
+		Context: formatter.Context{
+			Output: cli.out,
+			Format: f,
+			Trunc:  !*noTrunc,
+		},
+		Results: filtered,
+	}
+
+	searchCtx.Write()
+
 	return nil
 }
 
@@ -0,0 +1,185 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// sshHost holds the parts of the address of a daemon reached through ssh,
+// in the ssh://[user@]host[:port] form.
+type sshHost struct {
+	user string
+	host string
+	port string
+}
+
+// parseSSHHost parses the address of an ssh host, without the ssh:// prefix.
+// Passwords are not supported, the ssh client authenticates with its own
+// configuration, like keys or an agent.
+func parseSSHHost(addr string) (sshHost, error) {
+	u, err := url.Parse("ssh://" + addr)
+	if err != nil {
+		return sshHost{}, err
+	}
+	if u.Path != "" && u.Path != "/" || u.RawQuery != "" || u.Fragment != "" {
+		return sshHost{}, fmt.Errorf("invalid ssh host %s: paths and queries are not supported", addr)
+	}
+
+	var h sshHost
+	if u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			return sshHost{}, fmt.Errorf("invalid ssh host %s: passwords are not supported", addr)
+		}
+		h.user = u.User.Username()
+	}
+	h.host = u.Host
+	if host, port, err := net.SplitHostPort(u.Host); err == nil {
+		h.host, h.port = host, port
+	}
+	if h.host == "" {
+		return sshHost{}, fmt.Errorf("invalid ssh host %s: no host specified", addr)
+	}
+	return h, nil
+}
+
+// args returns the arguments of the ssh client to run the command on the
+// host.
+func (h sshHost) args(command ...string) []string {
+	var args []string
+	if h.user != "" {
+		args = append(args, "-l", h.user)
+	}
+	if h.port != "" {
+		args = append(args, "-p", h.port)
+	}
+	args = append(args, "--", h.host)
+	return append(args, command...)
+}
+
+// sshDial returns a dial function connecting to the daemon of the ssh host.
+// Every connection runs `docker dial-stdio` on the host, which proxies its
+// standard input and output to the daemon.
+func sshDial(addr string) (func(network, addr string) (net.Conn, error), error) {
+	h, err := parseSSHHost(addr)
+	if err != nil {
+		return nil, err
+	}
+	return func(_, _ string) (net.Conn, error) {
+		return newCommandConn("ssh", h.args("docker", "dial-stdio")...)
+	}, nil
+}
+
+// commandConn is a net.Conn reading from the standard output of a command,
+// and writing to its standard input.
+type commandConn struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout io.ReadCloser
+	stderr bytes.Buffer
+
+	waitOnce sync.Once
+	waitErr  error
+}
+
+// newCommandConn starts the command and returns a connection to it.
+func newCommandConn(name string, args ...string) (net.Conn, error) {
+	c := &commandConn{
+		cmd: exec.Command(name, args...),
+	}
+	c.cmd.Stderr = &c.stderr
+
+	var err error
+	if c.stdin, err = c.cmd.StdinPipe(); err != nil {
+		return nil, err
+	}
+	if c.stdout, err = c.cmd.StdoutPipe(); err != nil {
+		return nil, err
+	}
+	if err := c.cmd.Start(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// wait waits for the command to exit, and returns its error with what it
+// wrote to its standard error.
+func (c *commandConn) wait() error {
+	c.waitOnce.Do(func() {
+		if err := c.cmd.Wait(); err != nil {
+			c.waitErr = fmt.Errorf("%s: %v: %s", strings.Join(c.cmd.Args, " "), err, strings.TrimSpace(c.stderr.String()))
+		}
+	})
+	return c.waitErr
+}
+
+// Read reads from the standard output of the command. Once it is exhausted,
+// the error of the command is returned if it failed, as it is more helpful
+// than an unexpected EOF.
+func (c *commandConn) Read(p []byte) (int, error) {
+	n, err := c.stdout.Read(p)
+	if err == io.EOF {
+		if werr := c.wait(); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
+
+// Write writes to the standard input of the command.
+func (c *commandConn) Write(p []byte) (int, error) {
+	return c.stdin.Write(p)
+}
+
+// CloseWrite closes the standard input of the command.
+func (c *commandConn) CloseWrite() error {
+	return c.stdin.Close()
+}
+
+// Close closes the standard input of the command and kills it.
+func (c *commandConn) Close() error {
+	c.stdin.Close()
+	if c.cmd.Process != nil {
+		c.cmd.Process.Kill()
+	}
+	c.wait()
+	return nil
+}
+
+func (c *commandConn) LocalAddr() net.Addr {
+	return commandAddr{}
+}
+
+func (c *commandConn) RemoteAddr() net.Addr {
+	return commandAddr{}
+}
+
+// Deadlines are not supported by connections to commands.
+func (c *commandConn) SetDeadline(t time.Time) error {
+	return nil
+}
+
+func (c *commandConn) SetReadDeadline(t time.Time) error {
+	return nil
+}
+
+func (c *commandConn) SetWriteDeadline(t time.Time) error {
+	return nil
+}
+
+// commandAddr is the address of both ends of a commandConn.
+type commandAddr struct{}
+
+func (commandAddr) Network() string {
+	return "command"
+}
+
+func (commandAddr) String() string {
+	return "command"
+}
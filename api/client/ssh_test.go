@@ -0,0 +1,87 @@
+package client
+
+import (
+	"io/ioutil"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSSHHost(t *testing.T) {
+	cases := []struct {
+		addr     string
+		expArgs  []string
+		expError string
+	}{
+		{"example.com", []string{"--", "example.com", "docker", "dial-stdio"}, ""},
+		{"me@example.com", []string{"-l", "me", "--", "example.com", "docker", "dial-stdio"}, ""},
+		{"me@example.com:2222", []string{"-l", "me", "-p", "2222", "--", "example.com", "docker", "dial-stdio"}, ""},
+		{"example.com:2222/", []string{"-p", "2222", "--", "example.com", "docker", "dial-stdio"}, ""},
+		{"[::1]:2222", []string{"-p", "2222", "--", "::1", "docker", "dial-stdio"}, ""},
+		{"", nil, "no host specified"},
+		{"me@", nil, "no host specified"},
+		{"me:secret@example.com", nil, "passwords are not supported"},
+		{"example.com/var/run/docker.sock", nil, "paths and queries are not supported"},
+		{"example.com?socket=docker.sock", nil, "paths and queries are not supported"},
+	}
+
+	for _, c := range cases {
+		h, err := parseSSHHost(c.addr)
+		if c.expError != "" {
+			if err == nil || !strings.Contains(err.Error(), c.expError) {
+				t.Fatalf("Expected error containing %q for %q, got %v", c.expError, c.addr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error for %q: %v", c.addr, err)
+		}
+		if args := h.args("docker", "dial-stdio"); !reflect.DeepEqual(args, c.expArgs) {
+			t.Fatalf("Expected arguments %v for %q, got %v", c.expArgs, c.addr, args)
+		}
+	}
+}
+
+func TestCommandConn(t *testing.T) {
+	if _, err := exec.LookPath("cat"); err != nil {
+		t.Skip("cat is not available")
+	}
+
+	conn, err := newCommandConn("cat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.(*commandConn).CloseWrite(); err != nil {
+		t.Fatal(err)
+	}
+	out, err := ioutil.ReadAll(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != "hello" {
+		t.Fatalf("Expected hello, got %q", out)
+	}
+}
+
+func TestCommandConnError(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	conn, err := newCommandConn("sh", "-c", "echo failed >&2; exit 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	_, err = ioutil.ReadAll(conn)
+	if err == nil || !strings.Contains(err.Error(), "failed") {
+		t.Fatalf("Expected the error of the command, got %v", err)
+	}
+}
@@ -5,12 +5,12 @@ import (
 	"io"
 	"strings"
 	"sync"
-	"text/tabwriter"
 	"time"
 
 	"golang.org/x/net/context"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/client/formatter"
 	Cli "github.com/docker/docker/cli"
 	"github.com/docker/engine-api/types"
 	"github.com/docker/engine-api/types/events"
@@ -26,6 +26,7 @@ func (cli *DockerCli) CmdStats(args ...string) error {
 	cmd := Cli.Subcmd("stats", []string{"[CONTAINER...]"}, Cli.DockerCommands["stats"].Description, true)
 	all := cmd.Bool([]string{"a", "-all"}, false, "Show all containers (default shows just running)")
 	noStream := cmd.Bool([]string{"-no-stream"}, false, "Disable streaming stats and only pull the first result")
+	format := cmd.String([]string{"-format"}, "", "Pretty-print stats using a Go template")
 
 	cmd.ParseFlags(args, true)
 
@@ -159,25 +160,40 @@ func (cli *DockerCli) CmdStats(args ...string) error {
 	// before print to screen, make sure each container get at least one valid stat data
 	waitFirst.Wait()
 
-	w := tabwriter.NewWriter(cli.out, 20, 1, 3, ' ', 0)
-	printHeader := func() {
-		if !*noStream {
-			fmt.Fprint(cli.out, "\033[2J")
-			fmt.Fprint(cli.out, "\033[H")
+	f := *format
+	if len(f) == 0 {
+		if len(cli.StatsFormat()) > 0 {
+			f = cli.StatsFormat()
+		} else {
+			f = "table"
 		}
-		io.WriteString(w, "CONTAINER\tCPU %\tMEM USAGE / LIMIT\tMEM %\tNET I/O\tBLOCK I/O\tPIDS\n")
 	}
 
 	for range time.Tick(500 * time.Millisecond) {
-		printHeader()
+		if !*noStream {
+			fmt.Fprint(cli.out, "\033[2J")
+			fmt.Fprint(cli.out, "\033[H")
+		}
+		var entries []formatter.StatsEntry
 		cStats.mu.Lock()
 		for _, s := range cStats.cs {
-			if err := s.Display(w); err != nil && !*noStream {
+			entry, err := s.entry()
+			if err != nil && !*noStream {
 				logrus.Debugf("stats: got error for %s: %v", s.Name, err)
 			}
+			entries = append(entries, entry)
 		}
 		cStats.mu.Unlock()
-		w.Flush()
+
+		statsCtx := formatter.StatsContext{
+			Context: formatter.Context{
+				Output: cli.out,
+				Format: f,
+			},
+			Stats: entries,
+		}
+		statsCtx.Write()
+
 		if *noStream {
 			break
 		}
@@ -12,7 +12,7 @@ import (
 
 // CmdStop stops one or more containers.
 //
-// A running container is stopped by first sending SIGTERM and then SIGKILL if the container fails to stop within a grace period (the default is 10 seconds).
+// A running container is stopped by first sending SIGTERM and then SIGKILL if the container fails to stop within a grace period (the default is the stop timeout of the container, 10 seconds unless set at creation).
 //
 // Usage: docker stop [OPTIONS] CONTAINER [CONTAINER...]
 func (cli *DockerCli) CmdStop(args ...string) error {
@@ -22,9 +22,15 @@ func (cli *DockerCli) CmdStop(args ...string) error {
 
 	cmd.ParseFlags(args, true)
 
+	// Use the stop timeout of the container unless the flag is given
+	var timeout *int
+	if cmd.IsSet("t") || cmd.IsSet("-time") {
+		timeout = nSeconds
+	}
+
 	var errs []string
 	for _, name := range cmd.Args() {
-		if err := cli.client.ContainerStop(context.Background(), name, *nSeconds); err != nil {
+		if err := cli.client.ContainerStop(context.Background(), name, timeout); err != nil {
 			errs = append(errs, err.Error())
 		} else {
 			fmt.Fprintf(cli.out, "%s\n", name)
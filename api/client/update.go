@@ -7,8 +7,9 @@ import (
 	"golang.org/x/net/context"
 
 	Cli "github.com/docker/docker/cli"
+	"github.com/docker/docker/opts"
 	flag "github.com/docker/docker/pkg/mflag"
-	"github.com/docker/docker/runconfig/opts"
+	runconfigopts "github.com/docker/docker/runconfig/opts"
 	"github.com/docker/engine-api/types/container"
 	"github.com/docker/go-units"
 )
@@ -24,10 +25,14 @@ func (cli *DockerCli) CmdUpdate(args ...string) error {
 	flCpusetCpus := cmd.String([]string{"-cpuset-cpus"}, "", "CPUs in which to allow execution (0-3, 0,1)")
 	flCpusetMems := cmd.String([]string{"-cpuset-mems"}, "", "MEMs in which to allow execution (0-3, 0,1)")
 	flCPUShares := cmd.Int64([]string{"#c", "-cpu-shares"}, 0, "CPU shares (relative weight)")
+	var flCPUs opts.NanoCPUs
+	cmd.Var(&flCPUs, []string{"-cpus"}, "Number of CPUs")
 	flMemoryString := cmd.String([]string{"m", "-memory"}, "", "Memory limit")
 	flMemoryReservation := cmd.String([]string{"-memory-reservation"}, "", "Memory soft limit")
 	flMemorySwap := cmd.String([]string{"-memory-swap"}, "", "Swap limit equal to memory plus swap: '-1' to enable unlimited swap")
 	flKernelMemory := cmd.String([]string{"-kernel-memory"}, "", "Kernel memory limit")
+	var flNetworkRate runconfigopts.NetworkRateOpt
+	cmd.Var(&flNetworkRate, []string{"-network-rate"}, "Limit the network rate (bytes per second) of the container (ingress=<rate>,egress=<rate>)")
 	flRestartPolicy := cmd.String([]string{"-restart"}, "", "Restart policy to apply when a container exits")
 
 	cmd.Require(flag.Min, 1)
@@ -75,23 +80,26 @@ func (cli *DockerCli) CmdUpdate(args ...string) error {
 
 	var restartPolicy container.RestartPolicy
 	if *flRestartPolicy != "" {
-		restartPolicy, err = opts.ParseRestartPolicy(*flRestartPolicy)
+		restartPolicy, err = runconfigopts.ParseRestartPolicy(*flRestartPolicy)
 		if err != nil {
 			return err
 		}
 	}
 
 	resources := container.Resources{
-		BlkioWeight:       *flBlkioWeight,
-		CpusetCpus:        *flCpusetCpus,
-		CpusetMems:        *flCpusetMems,
-		CPUShares:         *flCPUShares,
-		Memory:            flMemory,
-		MemoryReservation: memoryReservation,
-		MemorySwap:        memorySwap,
-		KernelMemory:      kernelMemory,
-		CPUPeriod:         *flCPUPeriod,
-		CPUQuota:          *flCPUQuota,
+		BlkioWeight:        *flBlkioWeight,
+		CpusetCpus:         *flCpusetCpus,
+		CpusetMems:         *flCpusetMems,
+		CPUShares:          *flCPUShares,
+		NanoCPUs:           flCPUs.Value(),
+		Memory:             flMemory,
+		MemoryReservation:  memoryReservation,
+		MemorySwap:         memorySwap,
+		KernelMemory:       kernelMemory,
+		CPUPeriod:          *flCPUPeriod,
+		CPUQuota:           *flCPUQuota,
+		NetworkIngressRate: flNetworkRate.Ingress(),
+		NetworkEgressRate:  flNetworkRate.Egress(),
 	}
 
 	updateConfig := container.UpdateConfig{
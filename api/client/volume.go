@@ -3,10 +3,10 @@ package client
 import (
 	"fmt"
 	"sort"
-	"text/tabwriter"
 
 	"golang.org/x/net/context"
 
+	"github.com/docker/docker/api/client/formatter"
 	Cli "github.com/docker/docker/cli"
 	"github.com/docker/docker/opts"
 	flag "github.com/docker/docker/pkg/mflag"
@@ -47,6 +47,7 @@ func (cli *DockerCli) CmdVolumeLs(args ...string) error {
 	cmd := Cli.Subcmd("volume ls", nil, "List volumes", true)
 
 	quiet := cmd.Bool([]string{"q", "-quiet"}, false, "Only display volume names")
+	format := cmd.String([]string{"-format"}, "", "Pretty-print volumes using a Go template")
 	flFilter := opts.NewListOpts(nil)
 	cmd.Var(&flFilter, []string{"f", "-filter"}, "Provide filter values (i.e. 'dangling=true')")
 
@@ -67,24 +68,34 @@ func (cli *DockerCli) CmdVolumeLs(args ...string) error {
 		return err
 	}
 
-	w := tabwriter.NewWriter(cli.out, 20, 1, 3, ' ', 0)
 	if !*quiet {
 		for _, warn := range volumes.Warnings {
 			fmt.Fprintln(cli.err, warn)
 		}
-		fmt.Fprintf(w, "DRIVER \tVOLUME NAME")
-		fmt.Fprintf(w, "\n")
 	}
 
-	sort.Sort(byVolumeName(volumes.Volumes))
-	for _, vol := range volumes.Volumes {
-		if *quiet {
-			fmt.Fprintln(w, vol.Name)
-			continue
+	f := *format
+	if len(f) == 0 {
+		if len(cli.VolumesFormat()) > 0 && !*quiet {
+			f = cli.VolumesFormat()
+		} else {
+			f = "table"
 		}
-		fmt.Fprintf(w, "%s\t%s\n", vol.Driver, vol.Name)
 	}
-	w.Flush()
+
+	sort.Sort(byVolumeName(volumes.Volumes))
+
+	volumeCtx := formatter.VolumeContext{
+		Context: formatter.Context{
+			Output: cli.out,
+			Format: f,
+			Quiet:  *quiet,
+		},
+		Volumes: volumes.Volumes,
+	}
+
+	volumeCtx.Write()
+
 	return nil
 }
 
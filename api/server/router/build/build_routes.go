@@ -50,6 +50,7 @@ func newImageBuildOptions(ctx context.Context, r *http.Request) (*types.ImageBui
 	options.CPUSetCPUs = r.FormValue("cpusetcpus")
 	options.CPUSetMems = r.FormValue("cpusetmems")
 	options.CgroupParent = r.FormValue("cgroupparent")
+	options.NetworkMode = r.FormValue("networkmode")
 	options.Tags = r.Form["t"]
 
 	if r.Form.Get("shmsize") != "" {
@@ -93,6 +94,29 @@ func newImageBuildOptions(ctx context.Context, r *http.Request) (*types.ImageBui
 		options.Labels = labels
 	}
 
+	var outputs = []types.ImageBuildOutput{}
+	outputsJSON := r.FormValue("outputs")
+	if outputsJSON != "" {
+		if err := json.NewDecoder(strings.NewReader(outputsJSON)).Decode(&outputs); err != nil {
+			return nil, err
+		}
+		if len(outputs) > 1 {
+			return nil, fmt.Errorf("multiple outputs are not supported")
+		}
+		for _, o := range outputs {
+			if o.Type != "local" && o.Type != "tar" {
+				return nil, fmt.Errorf("unsupported output type: %q", o.Type)
+			}
+		}
+		if len(outputs) > 0 && options.SuppressOutput {
+			return nil, fmt.Errorf("outputs cannot be used with quiet mode")
+		}
+		if len(outputs) > 0 && len(options.Tags) > 0 {
+			return nil, fmt.Errorf("outputs cannot be used with tags")
+		}
+		options.Outputs = outputs
+	}
+
 	return options, nil
 }
 
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/docker/docker/api/server/httputils"
@@ -108,5 +109,26 @@ func (s *containerRouter) putContainersArchive(ctx context.Context, w http.Respo
 	}
 
 	noOverwriteDirNonDir := httputils.BoolValue(r, "noOverwriteDirNonDir")
-	return s.backend.ContainerExtractToDir(v.Name, v.Path, noOverwriteDirNonDir, r.Body)
+	copyUIDGID := httputils.BoolValue(r, "copyUIDGID")
+	return s.backend.ContainerExtractToDir(v.Name, v.Path, noOverwriteDirNonDir, copyUIDGID, r.Body)
+}
+
+func (s *containerRouter) postContainersArchive(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	v, err := httputils.ArchiveFormValues(r, vars)
+	if err != nil {
+		return err
+	}
+
+	fromContainer := r.Form.Get("fromContainer")
+	fromPath := filepath.FromSlash(r.Form.Get("fromPath"))
+	switch {
+	case fromContainer == "":
+		return fmt.Errorf("bad parameter: 'fromContainer' cannot be empty")
+	case fromPath == "":
+		return fmt.Errorf("bad parameter: 'fromPath' cannot be empty")
+	}
+
+	followLink := httputils.BoolValue(r, "followLink")
+	copyUIDGID := httputils.BoolValue(r, "copyUIDGID")
+	return s.backend.ContainerCopyBetween(fromContainer, fromPath, v.Name, v.Path, followLink, copyUIDGID)
 }
@@ -54,6 +54,7 @@ func (s *imageRouter) postCommit(ctx context.Context, w http.ResponseWriter, r *
 			Comment:      r.Form.Get("comment"),
 			Config:       c,
 			MergeConfigs: true,
+			Exclude:      r.Form["exclude"],
 		},
 		Changes: r.Form["changes"],
 	}
@@ -192,7 +193,7 @@ func (s *imageRouter) getImagesGet(ctx context.Context, w http.ResponseWriter, r
 		names = r.Form["names"]
 	}
 
-	if err := s.backend.ExportImage(names, output); err != nil {
+	if err := s.backend.ExportImage(names, output, r.Form.Get("format")); err != nil {
 		if !output.Flushed() {
 			return err
 		}
@@ -55,6 +55,8 @@ type ExecInspect struct {
 	CanRemove     bool
 	ContainerID   string
 	DetachKeys    []byte
+	Timeout       int
+	TimedOut      bool
 }
 
 // ExecProcessConfig holds information about the exec process
@@ -65,6 +67,7 @@ type ExecProcessConfig struct {
 	Arguments  []string `json:"arguments"`
 	Privileged *bool    `json:"privileged,omitempty"`
 	User       string   `json:"user,omitempty"`
+	WorkingDir string   `json:"workingDir,omitempty"`
 }
 
 // ContainerCommitConfig is a wrapper around
@@ -131,13 +131,26 @@ type Backend interface {
 	ContainerUpdateCmdOnBuild(containerID string, cmd []string) error
 
 	// ContainerCopy copies/extracts a source FileInfo to a destination path inside a container
-	// specified by a container object.
+	// specified by a container object. If chown is not empty, the copied files are owned by
+	// the user and group it specifies, resolved inside the container.
 	// TODO: make an Extract method instead of passing `decompress`
 	// TODO: do not pass a FileInfo, instead refactor the archive package to export a Walk function that can be used
 	// with Context.Walk
 	//ContainerCopy(name string, res string) (io.ReadCloser, error)
 	// TODO: use copyBackend api
-	CopyOnBuild(containerID string, destPath string, src FileInfo, decompress bool) error
+	CopyOnBuild(containerID string, destPath string, src FileInfo, decompress bool, chown string) error
+	// ContainerExport writes the contents of the container's root filesystem to out.
+	ContainerExport(containerID string, out io.Writer) error
+	// ContainerArchivePath creates an archive of the filesystem resource at the
+	// specified path in the container.
+	ContainerArchivePath(containerID string, path string) (io.ReadCloser, *types.ContainerPathStat, error)
+}
+
+// OutputChunk is a piece of the archive produced when a build is exported
+// instead of committed. Chunks are sent to the client, in order, as
+// auxiliary data of the build progress stream.
+type OutputChunk struct {
+	Data []byte
 }
 
 // Image represents a Docker image used by the builder.
@@ -192,7 +192,7 @@ func sanitizeRepoAndTags(names []string) ([]reference.Named, error) {
 // * walk the AST and execute it by dispatching to handlers. If Remove
 //   or ForceRemove is set, additional cleanup around containers happens after
 //   processing.
-// * Tag image, if applicable.
+// * Tag image, or export its filesystem to the client, if applicable.
 // * Print a happy message and return the image ID.
 //
 func (b *Builder) build(stdout io.Writer, stderr io.Writer, out io.Writer) (string, error) {
@@ -266,6 +266,14 @@ func (b *Builder) build(stdout io.Writer, stderr io.Writer, out io.Writer) (stri
 		return "", fmt.Errorf("No image was generated. Is your Dockerfile empty?")
 	}
 
+	if len(b.options.Outputs) > 0 {
+		if err := b.export(b.options.Outputs[0]); err != nil {
+			return "", err
+		}
+		fmt.Fprintf(b.Stdout, "Successfully exported %s\n", shortImgID)
+		return b.image, nil
+	}
+
 	imageID := image.ID(b.image)
 	for _, rt := range repoAndTags {
 		if err := b.docker.TagImageWithReference(imageID, rt); err != nil {
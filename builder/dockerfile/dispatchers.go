@@ -149,11 +149,12 @@ func add(b *Builder, args []string, attributes map[string]bool, original string)
 		return errAtLeastOneArgument("ADD")
 	}
 
+	flChown := b.flags.AddString("chown", "")
 	if err := b.flags.Parse(); err != nil {
 		return err
 	}
 
-	return b.runContextCommand(args, true, true, "ADD")
+	return b.runContextCommand(args, true, true, "ADD", flChown.Value)
 }
 
 // COPY foo /path
@@ -165,11 +166,12 @@ func dispatchCopy(b *Builder, args []string, attributes map[string]bool, origina
 		return errAtLeastOneArgument("COPY")
 	}
 
+	flChown := b.flags.AddString("chown", "")
 	if err := b.flags.Parse(); err != nil {
 		return err
 	}
 
-	return b.runContextCommand(args, false, false, "COPY")
+	return b.runContextCommand(args, false, false, "COPY", flChown.Value)
 }
 
 // FROM imagename
@@ -110,7 +110,7 @@ type copyInfo struct {
 	decompress bool
 }
 
-func (b *Builder) runContextCommand(args []string, allowRemote bool, allowLocalDecompression bool, cmdName string) error {
+func (b *Builder) runContextCommand(args []string, allowRemote bool, allowLocalDecompression bool, cmdName string, chown string) error {
 	if b.context == nil {
 		return fmt.Errorf("No context given. Impossible to use %s", cmdName)
 	}
@@ -189,6 +189,12 @@ func (b *Builder) runContextCommand(args []string, allowRemote bool, allowLocalD
 		origPaths = strings.Join(origs, " ")
 	}
 
+	// The ownership is part of the cache key, as the same source copied with
+	// a different owner produces a different layer.
+	if chown != "" {
+		cmdName = fmt.Sprintf("%s --chown=%s", cmdName, chown)
+	}
+
 	cmd := b.runConfig.Cmd
 	if runtime.GOOS != "windows" {
 		b.runConfig.Cmd = strslice.StrSlice{"/bin/sh", "-c", fmt.Sprintf("#(nop) %s %s in %s", cmdName, srcHash, dest)}
@@ -224,7 +230,7 @@ func (b *Builder) runContextCommand(args []string, allowRemote bool, allowLocalD
 	}
 
 	for _, info := range infos {
-		if err := b.docker.CopyOnBuild(container.ID, dest, info.FileInfo, info.decompress); err != nil {
+		if err := b.docker.CopyOnBuild(container.ID, dest, info.FileInfo, info.decompress, chown); err != nil {
 			return err
 		}
 	}
@@ -538,9 +544,10 @@ func (b *Builder) create() (string, error) {
 
 	// TODO: why not embed a hostconfig in builder?
 	hostConfig := &container.HostConfig{
-		Isolation: b.options.Isolation,
-		ShmSize:   b.options.ShmSize,
-		Resources: resources,
+		Isolation:   b.options.Isolation,
+		ShmSize:     b.options.ShmSize,
+		Resources:   resources,
+		NetworkMode: container.NetworkMode(b.options.NetworkMode),
 	}
 
 	config := *b.runConfig
@@ -635,6 +642,56 @@ func (b *Builder) clearTmp() {
 	}
 }
 
+// export streams the filesystem of the built image, or of the path in it
+// given by the "src" attribute, to the client as a tar archive. The client
+// decides whether the archive is unpacked into a directory or kept as is.
+func (b *Builder) export(output types.ImageBuildOutput) error {
+	config := *b.runConfig
+	config.Image = b.image
+	if runtime.GOOS != "windows" {
+		config.Cmd = strslice.StrSlice{"/bin/sh", "-c", "#(nop) export"}
+	} else {
+		config.Cmd = strslice.StrSlice{"cmd", "/S /C", "REM (nop) export"}
+	}
+
+	c, err := b.docker.ContainerCreate(types.ContainerCreateConfig{Config: &config})
+	if err != nil {
+		return err
+	}
+	defer b.removeContainer(c.ID)
+
+	stdoutFormatter := b.Stdout.(*streamformatter.StdoutFormatter)
+	w := &outputWriter{
+		out: stdoutFormatter.StreamFormatter.NewProgressOutput(stdoutFormatter.Writer, false),
+	}
+
+	src := output.Attrs["src"]
+	if src == "" || src == "/" {
+		return b.docker.ContainerExport(c.ID, w)
+	}
+
+	content, _, err := b.docker.ContainerArchivePath(c.ID, src)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+	_, err = io.Copy(w, content)
+	return err
+}
+
+// outputWriter writes each chunk it receives as auxiliary data to a
+// progress output.
+type outputWriter struct {
+	out progress.Output
+}
+
+func (w *outputWriter) Write(p []byte) (int, error) {
+	if err := w.out.WriteProgress(progress.Progress{Aux: builder.OutputChunk{Data: p}}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
 // readDockerfile reads a Dockerfile from the current context.
 func (b *Builder) readDockerfile() error {
 	// If no -f was specified then look for 'Dockerfile'. If we can't find
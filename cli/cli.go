@@ -55,7 +55,13 @@ func (cli *Cli) command(args ...string) (func(...string) error, error) {
 			if len(s) == 0 {
 				return nil, errors.New("empty command")
 			}
-			camelArgs[i] = strings.ToUpper(s[:1]) + strings.ToLower(s[1:])
+			// Hyphenated commands like dial-stdio are camel cased too.
+			for _, part := range strings.Split(s, "-") {
+				if len(part) == 0 {
+					return nil, errors.New("invalid command")
+				}
+				camelArgs[i] += strings.ToUpper(part[:1]) + strings.ToLower(part[1:])
+			}
 		}
 		methodName := "Cmd" + strings.Join(camelArgs, "")
 		method := reflect.ValueOf(c).MethodByName(methodName)
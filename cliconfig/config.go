@@ -52,11 +52,32 @@ type ConfigFile struct {
 	HTTPHeaders      map[string]string           `json:"HttpHeaders,omitempty"`
 	PsFormat         string                      `json:"psFormat,omitempty"`
 	ImagesFormat     string                      `json:"imagesFormat,omitempty"`
+	VolumesFormat    string                      `json:"volumesFormat,omitempty"`
+	NetworksFormat   string                      `json:"networksFormat,omitempty"`
+	StatsFormat      string                      `json:"statsFormat,omitempty"`
+	EventsFormat     string                      `json:"eventsFormat,omitempty"`
+	HistoryFormat    string                      `json:"historyFormat,omitempty"`
+	SearchFormat     string                      `json:"searchFormat,omitempty"`
+	InfoFormat       string                      `json:"infoFormat,omitempty"`
 	DetachKeys       string                      `json:"detachKeys,omitempty"`
 	CredentialsStore string                      `json:"credsStore,omitempty"`
+	Endpoints        map[string]Endpoint         `json:"endpoints,omitempty"`
+	DefaultEndpoint  string                      `json:"defaultEndpoint,omitempty"`
 	filename         string                      // Note: not serialized - for internal use only
 }
 
+// Endpoint is a named daemon the client can connect to, along with the TLS
+// material used to connect to it. Relative paths of TLS files are relative to
+// the configuration directory.
+type Endpoint struct {
+	Host      string `json:"host"`
+	TLS       bool   `json:"tls,omitempty"`
+	TLSVerify bool   `json:"tlsverify,omitempty"`
+	CAFile    string `json:"tlscacert,omitempty"`
+	CertFile  string `json:"tlscert,omitempty"`
+	KeyFile   string `json:"tlskey,omitempty"`
+}
+
 // NewConfigFile initializes an empty configuration file for the given filename 'fn'
 func NewConfigFile(fn string) *ConfigFile {
 	return &ConfigFile{
@@ -251,6 +272,15 @@ func (configFile *ConfigFile) Filename() string {
 	return configFile.filename
 }
 
+// Endpoint returns the endpoint with the given name.
+func (configFile *ConfigFile) Endpoint(name string) (Endpoint, error) {
+	endpoint, ok := configFile.Endpoints[name]
+	if !ok {
+		return Endpoint{}, fmt.Errorf("endpoint %q is not defined in the client configuration", name)
+	}
+	return endpoint, nil
+}
+
 // encodeAuth creates a base64 encoded string to containing authorization information
 func encodeAuth(authConfig *types.AuthConfig) string {
 	if authConfig.Username == "" && authConfig.Password == "" {
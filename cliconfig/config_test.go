@@ -174,7 +174,7 @@ func TestOldValidAuth(t *testing.T) {
 	// defaultIndexserver is https://index.docker.io/v1/
 	ac := config.AuthConfigs["https://index.docker.io/v1/"]
 	if ac.Username != "joejoe" || ac.Password != "hello" {
-		t.Fatalf("Missing data from parsing:\n%q", config)
+		t.Fatalf("Missing data from parsing:\n%v", config)
 	}
 
 	// Now save it and make sure it shows up in new form
@@ -245,7 +245,7 @@ func TestOldJson(t *testing.T) {
 
 	ac := config.AuthConfigs["https://index.docker.io/v1/"]
 	if ac.Username != "joejoe" || ac.Password != "hello" {
-		t.Fatalf("Missing data from parsing:\n%q", config)
+		t.Fatalf("Missing data from parsing:\n%v", config)
 	}
 
 	// Now save it and make sure it shows up in new form
@@ -285,7 +285,7 @@ func TestNewJson(t *testing.T) {
 
 	ac := config.AuthConfigs["https://index.docker.io/v1/"]
 	if ac.Username != "joejoe" || ac.Password != "hello" {
-		t.Fatalf("Missing data from parsing:\n%q", config)
+		t.Fatalf("Missing data from parsing:\n%v", config)
 	}
 
 	// Now save it and make sure it shows up in new form
@@ -324,7 +324,7 @@ func TestNewJsonNoEmail(t *testing.T) {
 
 	ac := config.AuthConfigs["https://index.docker.io/v1/"]
 	if ac.Username != "joejoe" || ac.Password != "hello" {
-		t.Fatalf("Missing data from parsing:\n%q", config)
+		t.Fatalf("Missing data from parsing:\n%v", config)
 	}
 
 	// Now save it and make sure it shows up in new form
@@ -430,7 +430,7 @@ func TestJsonReaderNoFile(t *testing.T) {
 
 	ac := config.AuthConfigs["https://index.docker.io/v1/"]
 	if ac.Username != "joejoe" || ac.Password != "hello" {
-		t.Fatalf("Missing data from parsing:\n%q", config)
+		t.Fatalf("Missing data from parsing:\n%v", config)
 	}
 
 }
@@ -445,7 +445,7 @@ func TestOldJsonReaderNoFile(t *testing.T) {
 
 	ac := config.AuthConfigs["https://index.docker.io/v1/"]
 	if ac.Username != "joejoe" || ac.Password != "hello" {
-		t.Fatalf("Missing data from parsing:\n%q", config)
+		t.Fatalf("Missing data from parsing:\n%v", config)
 	}
 }
 
@@ -465,6 +465,68 @@ func TestJsonWithPsFormatNoFile(t *testing.T) {
 
 }
 
+func TestJsonWithListFormatsNoFile(t *testing.T) {
+	js := `{
+		"volumesFormat": "table {{.Name}}",
+		"networksFormat": "table {{.ID}}\\t{{.Scope}}",
+		"statsFormat": "{{.Container}}: {{.CPUPerc}}",
+		"eventsFormat": "{{json .}}",
+		"historyFormat": "raw",
+		"searchFormat": "{{.Name}}",
+		"infoFormat": "{{.ServerVersion}}"
+}`
+	config, err := LoadFromReader(strings.NewReader(js))
+	if err != nil {
+		t.Fatalf("Failed loading on json file: %q", err)
+	}
+
+	formats := []struct {
+		actual, expected string
+	}{
+		{config.VolumesFormat, `table {{.Name}}`},
+		{config.NetworksFormat, `table {{.ID}}\t{{.Scope}}`},
+		{config.StatsFormat, `{{.Container}}: {{.CPUPerc}}`},
+		{config.EventsFormat, `{{json .}}`},
+		{config.HistoryFormat, `raw`},
+		{config.SearchFormat, `{{.Name}}`},
+		{config.InfoFormat, `{{.ServerVersion}}`},
+	}
+	for _, f := range formats {
+		if f.actual != f.expected {
+			t.Fatalf("Expected format %s, got %s\n", f.expected, f.actual)
+		}
+	}
+}
+
+func TestJsonWithEndpointsNoFile(t *testing.T) {
+	js := `{
+		"endpoints": {
+			"staging": { "host": "tcp://staging.example.com:2375" },
+			"production": { "host": "tcp://production.example.com:2376", "tlsverify": true, "tlscacert": "production/ca.pem" }
+		},
+		"defaultEndpoint": "staging"
+}`
+	config, err := LoadFromReader(strings.NewReader(js))
+	if err != nil {
+		t.Fatalf("Failed loading on json file: %q", err)
+	}
+
+	if config.DefaultEndpoint != "staging" {
+		t.Fatalf("Expected default endpoint staging, got %s", config.DefaultEndpoint)
+	}
+	endpoint, err := config.Endpoint("production")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Endpoint{Host: "tcp://production.example.com:2376", TLSVerify: true, CAFile: "production/ca.pem"}
+	if endpoint != expected {
+		t.Fatalf("Expected endpoint %v, got %v", expected, endpoint)
+	}
+	if _, err := config.Endpoint("unknown"); err == nil {
+		t.Fatal("Expected an error for an unknown endpoint")
+	}
+}
+
 func TestJsonSaveWithNoFile(t *testing.T) {
 	js := `{
 		"auths": { "https://index.docker.io/v1/": { "auth": "am9lam9lOmhlbGxv" } },
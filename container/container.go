@@ -37,12 +37,17 @@ import (
 	"github.com/docker/libnetwork"
 	"github.com/docker/libnetwork/netlabel"
 	"github.com/docker/libnetwork/options"
+	"github.com/docker/libnetwork/portallocator"
 	"github.com/docker/libnetwork/types"
 	"github.com/opencontainers/runc/libcontainer/label"
 )
 
 const configFileName = "config.v2.json"
 
+// DefaultStopTimeout is the timeout (in seconds) for the stop of a
+// container, after which it is killed.
+const DefaultStopTimeout = 10
+
 var (
 	errInvalidEndpoint = fmt.Errorf("invalid endpoint while building port map info")
 	errInvalidNetwork  = fmt.Errorf("invalid network settings while building port map info")
@@ -554,6 +559,14 @@ func (container *Container) StopSignal() int {
 	return int(stopSignal)
 }
 
+// StopTimeout returns the timeout (in seconds) used to stop the container.
+func (container *Container) StopTimeout() int {
+	if container.Config.StopTimeout != nil {
+		return *container.Config.StopTimeout
+	}
+	return DefaultStopTimeout
+}
+
 // InitDNSHostConfig ensures that the dns fields are never nil.
 // New containers don't ever have those fields nil,
 // but pre created containers can still have those nil values.
@@ -714,6 +727,7 @@ func (container *Container) UpdateJoinInfo(n libnetwork.Network, ep libnetwork.E
 	if err := container.buildPortMapInfo(ep); err != nil {
 		return err
 	}
+	container.recordPublishedPorts()
 
 	epInfo := ep.Info()
 	if epInfo == nil {
@@ -783,6 +797,13 @@ func (container *Container) BuildCreateEndpointOptions(n libnetwork.Network, epC
 		createOptions = append(createOptions, libnetwork.CreateOptionDisableResolution())
 	}
 
+	// The labels of the container are matched by the policies of the networks
+	if len(container.Config.Labels) > 0 {
+		createOptions = append(createOptions, libnetwork.EndpointOptionGeneric(options.Generic{
+			netlabel.ContainerLabels: container.Config.Labels,
+		}))
+	}
+
 	// configs that are applicable only for the endpoint in the network
 	// to which container was connected to on docker run.
 	// Ideally all these network-specific endpoint configurations must be moved under
@@ -847,6 +868,9 @@ func (container *Container) BuildCreateEndpointOptions(n libnetwork.Network, epC
 			if err != nil {
 				return nil, fmt.Errorf("Error parsing HostPort value(%s):%v", binding[i].HostPort, err)
 			}
+			if hostPort := container.publishedHostPort(port, i, binding[i].HostIP, portStart, portEnd); hostPort != 0 {
+				portStart, portEnd = hostPort, hostPort
+			}
 			pbCopy.HostPort = uint16(portStart)
 			pbCopy.HostPortEnd = uint16(portEnd)
 			pbCopy.HostIP = net.ParseIP(binding[i].HostIP)
@@ -854,6 +878,10 @@ func (container *Container) BuildCreateEndpointOptions(n libnetwork.Network, epC
 		}
 
 		if container.HostConfig.PublishAllPorts && len(binding) == 0 {
+			if hostPort := container.publishedHostPort(port, 0, "", 0, 0); hostPort != 0 {
+				pb.HostPort = uint16(hostPort)
+				pb.HostPortEnd = uint16(hostPort)
+			}
 			pbList = append(pbList, pb)
 		}
 	}
@@ -865,6 +893,82 @@ func (container *Container) BuildCreateEndpointOptions(n libnetwork.Network, epC
 	return createOptions, nil
 }
 
+// publishedHostPort returns the host port picked for the binding of the port
+// the last time the container ran, to publish the port on the same host port.
+// It returns 0 if the binding has a fixed host port, if no port was picked
+// for it, if the port is out of the range of the binding or not free anymore.
+func (container *Container) publishedHostPort(port nat.Port, i int, hostIP string, portStart, portEnd int) int {
+	if portStart != 0 && portStart == portEnd {
+		return 0
+	}
+	published := container.HostConfig.PublishedPorts[port]
+	if i >= len(published) {
+		return 0
+	}
+	if hostIP != "" && published[i].HostIP != hostIP {
+		return 0
+	}
+	hostPort, err := strconv.Atoi(published[i].HostPort)
+	if err != nil || hostPort == 0 {
+		return 0
+	}
+
+	if portStart == 0 {
+		// The port must still be in the ephemeral port range, which can
+		// be changed with the daemon configuration.
+		portStart, portEnd = portallocator.Get().PortRange()
+	}
+	if hostPort < portStart || hostPort > portEnd {
+		return 0
+	}
+	if !isPortFree(published[i].HostIP, port.Proto(), hostPort) {
+		return 0
+	}
+	return hostPort
+}
+
+// isPortFree returns whether the host port is neither allocated to another
+// container nor used on the host.
+func isPortFree(hostIP, proto string, port int) bool {
+	ip := net.ParseIP(hostIP)
+	allocator := portallocator.Get()
+	if _, err := allocator.RequestPort(ip, proto, port); err != nil {
+		return false
+	}
+	allocator.ReleasePort(ip, proto, port)
+
+	addr := net.JoinHostPort(hostIP, strconv.Itoa(port))
+	if proto == "udp" {
+		l, err := net.ListenPacket(proto, addr)
+		if err != nil {
+			return false
+		}
+		l.Close()
+		return true
+	}
+	l, err := net.Listen(proto, addr)
+	if err != nil {
+		return false
+	}
+	l.Close()
+	return true
+}
+
+// recordPublishedPorts records the host ports the ports of the container are
+// published on, to publish them on the same host ports when the container
+// restarts.
+func (container *Container) recordPublishedPorts() {
+	for port, bindings := range container.NetworkSettings.Ports {
+		if len(bindings) == 0 {
+			continue
+		}
+		if container.HostConfig.PublishedPorts == nil {
+			container.HostConfig.PublishedPorts = nat.PortMap{}
+		}
+		container.HostConfig.PublishedPorts[port] = append([]nat.PortBinding(nil), bindings...)
+	}
+}
+
 // UpdateMonitor updates monitor configure for running container
 func (container *Container) UpdateMonitor(restartPolicy containertypes.RestartPolicy) {
 	type policySetter interface {
@@ -1,10 +1,13 @@
 package container
 
 import (
+	"net"
+	"strconv"
 	"testing"
 
 	"github.com/docker/docker/pkg/signal"
 	"github.com/docker/engine-api/types/container"
+	"github.com/docker/go-connections/nat"
 )
 
 func TestContainerStopSignal(t *testing.T) {
@@ -34,3 +37,82 @@ func TestContainerStopSignal(t *testing.T) {
 		t.Fatalf("Expected 9, got %v", s)
 	}
 }
+
+func TestContainerStopTimeout(t *testing.T) {
+	c := &Container{
+		CommonContainer: CommonContainer{
+			Config: &container.Config{},
+		},
+	}
+
+	s := c.StopTimeout()
+	if s != DefaultStopTimeout {
+		t.Fatalf("Expected %v, got %v", DefaultStopTimeout, s)
+	}
+
+	stopTimeout := 15
+	c = &Container{
+		CommonContainer: CommonContainer{
+			Config: &container.Config{StopTimeout: &stopTimeout},
+		},
+	}
+	s = c.StopTimeout()
+	if s != 15 {
+		t.Fatalf("Expected 15, got %v", s)
+	}
+}
+
+func TestContainerPublishedHostPort(t *testing.T) {
+	// Find a free port, and one in use
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	used := l.Addr().(*net.TCPAddr).Port
+	l2, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	free := l2.Addr().(*net.TCPAddr).Port
+	l2.Close()
+
+	port := nat.Port("80/tcp")
+	c := &Container{
+		CommonContainer: CommonContainer{
+			HostConfig: &container.HostConfig{
+				PublishedPorts: nat.PortMap{
+					port: []nat.PortBinding{
+						{HostIP: "127.0.0.1", HostPort: strconv.Itoa(free)},
+						{HostIP: "127.0.0.1", HostPort: strconv.Itoa(used)},
+					},
+				},
+			},
+		},
+	}
+
+	if p := c.publishedHostPort(port, 0, "", free, free+1); p != free {
+		t.Fatalf("Expected the published port %d to be reused, got %d", free, p)
+	}
+	if p := c.publishedHostPort(port, 0, "127.0.0.1", free-1, free); p != free {
+		t.Fatalf("Expected the published port %d to be reused, got %d", free, p)
+	}
+	if p := c.publishedHostPort(port, 0, "", free, free); p != 0 {
+		t.Fatalf("Expected a fixed host port to be kept, got %d", p)
+	}
+	if p := c.publishedHostPort(port, 0, "", free+1, free+10); p != 0 {
+		t.Fatalf("Expected a port out of the range not to be reused, got %d", p)
+	}
+	if p := c.publishedHostPort(port, 0, "10.0.0.1", free, free+1); p != 0 {
+		t.Fatalf("Expected a port published on another address not to be reused, got %d", p)
+	}
+	if p := c.publishedHostPort(port, 1, "", used, used+1); p != 0 {
+		t.Fatalf("Expected a port in use not to be reused, got %d", p)
+	}
+	if p := c.publishedHostPort(port, 2, "", free, free+1); p != 0 {
+		t.Fatalf("Expected no port for a new binding, got %d", p)
+	}
+	if p := c.publishedHostPort(nat.Port("81/tcp"), 0, "", free, free+1); p != 0 {
+		t.Fatalf("Expected no port for a new port, got %d", p)
+	}
+}
@@ -12,11 +12,13 @@ import (
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/pkg/chrootarchive"
+	"github.com/docker/docker/pkg/idtools"
 	"github.com/docker/docker/pkg/symlink"
 	"github.com/docker/docker/pkg/system"
 	"github.com/docker/docker/utils"
 	"github.com/docker/docker/volume"
 	containertypes "github.com/docker/engine-api/types/container"
+	mounttypes "github.com/docker/engine-api/types/mount"
 	"github.com/opencontainers/runc/libcontainer/label"
 )
 
@@ -36,6 +38,11 @@ type Container struct {
 	ResolvConfPath  string
 	SeccompProfile  string
 	NoNewPrivileges bool
+
+	// UIDMaps and GIDMaps are the user namespace mappings of a container
+	// running with an id mapping of its own (--userns=isolated).
+	UIDMaps []idtools.IDMap `json:",omitempty"`
+	GIDMaps []idtools.IDMap `json:",omitempty"`
 }
 
 // ExitStatus provides exit reasons for a container.
@@ -257,6 +264,19 @@ func (container *Container) UpdateContainer(hostConfig *containertypes.HostConfi
 	if resources.CPUShares != 0 {
 		cResources.CPUShares = resources.CPUShares
 	}
+	// NanoCPUs is mutually exclusive with CPUPeriod and CPUQuota, as
+	// they all set the CFS quota of the container.
+	if resources.CPUPeriod != 0 || resources.CPUQuota != 0 {
+		if cResources.NanoCPUs != 0 {
+			return fmt.Errorf("Conflicting options: CPU Quota or CPU Period cannot be updated as NanoCPUs has already been set")
+		}
+	}
+	if resources.NanoCPUs != 0 {
+		if cResources.CPUPeriod != 0 || cResources.CPUQuota != 0 {
+			return fmt.Errorf("Conflicting options: Nano CPUs cannot be updated as CPU Period/Quota has already been set")
+		}
+		cResources.NanoCPUs = resources.NanoCPUs
+	}
 	if resources.CPUPeriod != 0 {
 		cResources.CPUPeriod = resources.CPUPeriod
 	}
@@ -281,6 +301,12 @@ func (container *Container) UpdateContainer(hostConfig *containertypes.HostConfi
 	if resources.KernelMemory != 0 {
 		cResources.KernelMemory = resources.KernelMemory
 	}
+	if resources.NetworkIngressRate != 0 {
+		cResources.NetworkIngressRate = resources.NetworkIngressRate
+	}
+	if resources.NetworkEgressRate != 0 {
+		cResources.NetworkEgressRate = resources.NetworkEgressRate
+	}
 
 	// update HostConfig of container
 	if hostConfig.RestartPolicy.Name != "" {
@@ -390,6 +416,15 @@ func (container *Container) TmpfsMounts() []Mount {
 			Data:        data,
 		})
 	}
+	for _, m := range container.HostConfig.Mounts {
+		if m.Type == mounttypes.TypeTmpfs {
+			mounts = append(mounts, Mount{
+				Source:      "tmpfs",
+				Destination: filepath.Clean(m.Target),
+				Data:        volume.ConvertTmpfsOptions(m.TmpfsOptions, m.ReadOnly),
+			})
+		}
+	}
 	return mounts
 }
 
@@ -3,6 +3,7 @@ package daemon
 import (
 	"errors"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -13,6 +14,7 @@ import (
 	"github.com/docker/docker/pkg/chrootarchive"
 	"github.com/docker/docker/pkg/idtools"
 	"github.com/docker/docker/pkg/ioutils"
+	"github.com/docker/docker/pkg/system"
 	"github.com/docker/engine-api/types"
 )
 
@@ -56,7 +58,7 @@ func (daemon *Daemon) ContainerArchivePath(name string, path string) (content io
 		return nil, nil, err
 	}
 
-	return daemon.containerArchivePath(container, path)
+	return daemon.containerArchivePath(container, path, false)
 }
 
 // ContainerExtractToDir extracts the given archive to the specified location
@@ -64,14 +66,114 @@ func (daemon *Daemon) ContainerArchivePath(name string, path string) (content io
 // path must be of a directory in the container. If it is not, the error will
 // be ErrExtractPointNotDirectory. If noOverwriteDirNonDir is true then it will
 // be an error if unpacking the given content would cause an existing directory
-// to be replaced with a non-directory and vice versa.
-func (daemon *Daemon) ContainerExtractToDir(name, path string, noOverwriteDirNonDir bool, content io.Reader) error {
+// to be replaced with a non-directory and vice versa. If copyUIDGID is true,
+// the extracted files keep the uid and gid of the archive, taken as ids in the
+// user namespace of the container, otherwise they are owned by its root.
+func (daemon *Daemon) ContainerExtractToDir(name, path string, noOverwriteDirNonDir, copyUIDGID bool, content io.Reader) error {
 	container, err := daemon.GetContainer(name)
 	if err != nil {
 		return err
 	}
 
-	return daemon.containerExtractToDir(container, path, noOverwriteDirNonDir, content)
+	return daemon.containerExtractToDir(container, path, noOverwriteDirNonDir, copyUIDGID, content)
+}
+
+// ContainerCopyBetween copies the filesystem resource at srcPath in the
+// container identified by srcName to dstPath in the container identified by
+// dstName, the same way a client copies it from one container to the other.
+// If followLink is true, a symlink at srcPath is followed and its target is
+// copied. If copyUIDGID is true, the copied files keep their uid and gid in
+// the user namespace of the source container.
+func (daemon *Daemon) ContainerCopyBetween(srcName, srcPath, dstName, dstPath string, followLink, copyUIDGID bool) error {
+	srcContainer, err := daemon.GetContainer(srcName)
+	if err != nil {
+		return err
+	}
+	dstContainer, err := daemon.GetContainer(dstName)
+	if err != nil {
+		return err
+	}
+
+	// If a symlink is followed, the archive entries are rebased on the name
+	// of the link rather than of its target.
+	var rebaseName string
+	if followLink {
+		srcStat, err := daemon.containerStatPath(srcContainer, srcPath)
+		if err == nil && srcStat.Mode&os.ModeSymlink != 0 {
+			linkTarget := srcStat.LinkTarget
+			if !system.IsAbs(linkTarget) {
+				// Join with the parent directory.
+				srcParent, _ := archive.SplitPathDirEntry(srcPath)
+				linkTarget = filepath.Join(srcParent, linkTarget)
+			}
+			srcPath, rebaseName = archive.GetRebaseName(srcPath, linkTarget)
+		}
+	}
+
+	// Stat the destination, evaluating it if it is a symlink. As when
+	// copying from a client, a missing destination is assumed to have an
+	// existing parent directory.
+	dstInfo := archive.CopyInfo{Path: dstPath}
+	dstStat, err := daemon.containerStatPath(dstContainer, dstPath)
+	if err == nil && dstStat.Mode&os.ModeSymlink != 0 {
+		linkTarget := dstStat.LinkTarget
+		if !system.IsAbs(linkTarget) {
+			// Join with the parent directory.
+			dstParent, _ := archive.SplitPathDirEntry(dstPath)
+			linkTarget = filepath.Join(dstParent, linkTarget)
+		}
+		dstInfo.Path = linkTarget
+		dstStat, err = daemon.containerStatPath(dstContainer, linkTarget)
+	}
+	if err == nil {
+		dstInfo.Exists, dstInfo.IsDir = true, dstStat.Mode.IsDir()
+	}
+
+	// The archive is spooled to a temporary file, so that the source
+	// container is unlocked before the destination container is locked.
+	// Holding both locks could deadlock with a copy in the other direction,
+	// or with the source container itself.
+	content, srcStat, err := daemon.containerArchivePath(srcContainer, srcPath, copyUIDGID)
+	if err != nil {
+		return err
+	}
+	spool, err := ioutil.TempFile("", "docker-cp-")
+	if err != nil {
+		content.Close()
+		return err
+	}
+	defer func() {
+		spool.Close()
+		os.Remove(spool.Name())
+	}()
+	_, err = io.Copy(spool, content)
+	content.Close()
+	if err != nil {
+		return err
+	}
+	if _, err := spool.Seek(0, 0); err != nil {
+		return err
+	}
+
+	srcInfo := archive.CopyInfo{
+		Path:       srcPath,
+		Exists:     true,
+		IsDir:      srcStat.Mode.IsDir(),
+		RebaseName: rebaseName,
+	}
+	var srcArchive io.Reader = spool
+	if rebaseName != "" {
+		_, srcBase := archive.SplitPathDirEntry(srcPath)
+		srcArchive = archive.RebaseArchiveEntries(spool, srcBase, rebaseName)
+	}
+
+	dstDir, preparedArchive, err := archive.PrepareArchiveCopy(srcArchive, srcInfo, dstInfo)
+	if err != nil {
+		return err
+	}
+	defer preparedArchive.Close()
+
+	return daemon.containerExtractToDir(dstContainer, dstDir, true, copyUIDGID, preparedArchive)
 }
 
 // containerStatPath stats the filesystem resource at the specified path in this
@@ -101,8 +203,9 @@ func (daemon *Daemon) containerStatPath(container *container.Container, path str
 
 // containerArchivePath creates an archive of the filesystem resource at the specified
 // path in this container. Returns a tar archive of the resource and stat info
-// about the resource.
-func (daemon *Daemon) containerArchivePath(container *container.Container, path string) (content io.ReadCloser, stat *types.ContainerPathStat, err error) {
+// about the resource. If containerIDs is true, the uid and gid of the archived
+// files are those of the user namespace of the container rather than the host.
+func (daemon *Daemon) containerArchivePath(container *container.Container, path string, containerIDs bool) (content io.ReadCloser, stat *types.ContainerPathStat, err error) {
 	container.Lock()
 
 	defer func() {
@@ -149,7 +252,23 @@ func (daemon *Daemon) containerArchivePath(container *container.Container, path
 	// also catches the case when the root directory of the container is
 	// requested: we want the archive entries to start with "/" and not the
 	// container ID.
-	data, err := archive.TarResourceRebase(resolvedPath, filepath.Base(absPath))
+	var data archive.Archive
+	if containerIDs {
+		uidMaps, gidMaps := daemon.getContainerIDMaps(container)
+		sourceDir, sourceBase := archive.SplitPathDirEntry(resolvedPath)
+		data, err = archive.TarWithOptions(sourceDir, &archive.TarOptions{
+			Compression:      archive.Uncompressed,
+			IncludeFiles:     []string{sourceBase},
+			IncludeSourceDir: true,
+			RebaseNames: map[string]string{
+				sourceBase: filepath.Base(absPath),
+			},
+			UIDMaps: uidMaps,
+			GIDMaps: gidMaps,
+		})
+	} else {
+		data, err = archive.TarResourceRebase(resolvedPath, filepath.Base(absPath))
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -172,8 +291,10 @@ func (daemon *Daemon) containerArchivePath(container *container.Container, path
 // container. If it is not, the error will be ErrExtractPointNotDirectory. If
 // noOverwriteDirNonDir is true then it will be an error if unpacking the
 // given content would cause an existing directory to be replaced with a non-
-// directory and vice versa.
-func (daemon *Daemon) containerExtractToDir(container *container.Container, path string, noOverwriteDirNonDir bool, content io.Reader) (err error) {
+// directory and vice versa. If copyUIDGID is true, the uid and gid of the
+// archive are mapped to the host through the user namespace of the container,
+// otherwise the extracted files are owned by the root of the container.
+func (daemon *Daemon) containerExtractToDir(container *container.Container, path string, noOverwriteDirNonDir, copyUIDGID bool, content io.Reader) (err error) {
 	container.Lock()
 	defer container.Unlock()
 
@@ -250,12 +371,16 @@ func (daemon *Daemon) containerExtractToDir(container *container.Container, path
 		return ErrRootFSReadOnly
 	}
 
-	uid, gid := daemon.GetRemappedUIDGID()
 	options := &archive.TarOptions{
 		NoOverwriteDirNonDir: noOverwriteDirNonDir,
-		ChownOpts: &archive.TarChownOptions{
-			UID: uid, GID: gid, // TODO: should all ownership be set to root (either real or remapped)?
-		},
+	}
+	if copyUIDGID {
+		options.UIDMaps, options.GIDMaps = daemon.getContainerIDMaps(container)
+	} else {
+		uid, gid := daemon.getContainerRemappedUIDGID(container)
+		options.ChownOpts = &archive.TarChownOptions{
+			UID: uid, GID: gid,
+		}
 	}
 	if err := chrootarchive.Untar(content, resolvedPath, options); err != nil {
 		return err
@@ -332,10 +457,11 @@ func (daemon *Daemon) containerCopy(container *container.Container, resource str
 }
 
 // CopyOnBuild copies/extracts a source FileInfo to a destination path inside a container
-// specified by a container object.
+// specified by a container object. The copied files are owned by the remapped root, or by
+// the user and group given in chown if it is not empty.
 // TODO: make sure callers don't unnecessarily convert destPath with filepath.FromSlash (Copy does it already).
 // CopyOnBuild should take in abstract paths (with slashes) and the implementation should convert it to OS-specific paths.
-func (daemon *Daemon) CopyOnBuild(cID string, destPath string, src builder.FileInfo, decompress bool) error {
+func (daemon *Daemon) CopyOnBuild(cID string, destPath string, src builder.FileInfo, decompress bool, chown string) error {
 	srcPath := src.Path()
 	destExists := true
 	destDir := false
@@ -354,6 +480,14 @@ func (daemon *Daemon) CopyOnBuild(cID string, destPath string, src builder.FileI
 	}
 	defer daemon.Unmount(c)
 
+	ownerUID, ownerGID := rootUID, rootGID
+	if chown != "" {
+		ownerUID, ownerGID, err = daemon.getCopyOwnership(c, chown)
+		if err != nil {
+			return err
+		}
+	}
+
 	dest, err := c.GetResourcePath(destPath)
 	if err != nil {
 		return err
@@ -389,7 +523,7 @@ func (daemon *Daemon) CopyOnBuild(cID string, destPath string, src builder.FileI
 		if err := archiver.CopyWithTar(srcPath, destPath); err != nil {
 			return err
 		}
-		return fixPermissions(srcPath, destPath, rootUID, rootGID, destExists)
+		return fixPermissions(srcPath, destPath, ownerUID, ownerGID, destExists)
 	}
 	if decompress && archive.IsArchivePath(srcPath) {
 		// Only try to untar if it is a file and that we've been told to decompress (when ADD-ing a remote file)
@@ -425,5 +559,5 @@ func (daemon *Daemon) CopyOnBuild(cID string, destPath string, src builder.FileI
 		return err
 	}
 
-	return fixPermissions(srcPath, destPath, rootUID, rootGID, destExists)
+	return fixPermissions(srcPath, destPath, ownerUID, ownerGID, destExists)
 }
@@ -3,9 +3,14 @@
 package daemon
 
 import (
-	"github.com/docker/docker/container"
+	"io"
 	"os"
 	"path/filepath"
+
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/idtools"
+	"github.com/docker/docker/pkg/symlink"
+	"github.com/opencontainers/runc/libcontainer/user"
 )
 
 // checkIfPathIsInAVolume checks if the path is in a volume. If it is, it
@@ -55,3 +60,56 @@ func fixPermissions(source, destination string, uid, gid int, destExisted bool)
 		return os.Lchown(fullpath, uid, gid)
 	})
 }
+
+// getCopyOwnership resolves the user and group given to the --chown flag of
+// ADD and COPY against the /etc/passwd and /etc/group files of the container,
+// and returns the matching IDs on the host. The container must be mounted.
+func (daemon *Daemon) getCopyOwnership(c *container.Container, chown string) (int, int, error) {
+	passwd, err := openContainerUserFile(c, "/etc/passwd")
+	if err != nil {
+		return -1, -1, err
+	}
+	if passwd != nil {
+		defer passwd.Close()
+	}
+	group, err := openContainerUserFile(c, "/etc/group")
+	if err != nil {
+		return -1, -1, err
+	}
+	if group != nil {
+		defer group.Close()
+	}
+
+	execUser, err := user.GetExecUser(chown, nil, passwd, group)
+	if err != nil {
+		return -1, -1, err
+	}
+
+	uidMaps, gidMaps := daemon.GetUIDGIDMaps()
+	uid, err := idtools.ToHost(execUser.Uid, uidMaps)
+	if err != nil {
+		return -1, -1, err
+	}
+	gid, err := idtools.ToHost(execUser.Gid, gidMaps)
+	if err != nil {
+		return -1, -1, err
+	}
+	return uid, gid, nil
+}
+
+// openContainerUserFile opens the file at path p inside the root filesystem
+// of the container. It returns nil if the file does not exist.
+func openContainerUserFile(c *container.Container, p string) (io.ReadCloser, error) {
+	fp, err := symlink.FollowSymlinkInScope(filepath.Join(c.BaseFS, p), c.BaseFS)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(fp)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return f, nil
+}
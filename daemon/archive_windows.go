@@ -1,6 +1,10 @@
 package daemon
 
-import "github.com/docker/docker/container"
+import (
+	"fmt"
+
+	"github.com/docker/docker/container"
+)
 
 // checkIfPathIsInAVolume checks if the path is in a volume. If it is, it
 // cannot be in a read-only volume. If it  is not in a volume, the container
@@ -16,3 +20,7 @@ func fixPermissions(source, destination string, uid, gid int, destExisted bool)
 	// chown is not supported on Windows
 	return nil
 }
+
+func (daemon *Daemon) getCopyOwnership(c *container.Container, chown string) (int, int, error) {
+	return -1, -1, fmt.Errorf("--chown is not supported on Windows")
+}
@@ -1,6 +1,19 @@
 package daemon
 
-import "github.com/docker/docker/pkg/archive"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/symlink"
+	"github.com/docker/engine-api/types"
+	"github.com/vbatts/tar-split/tar/storage"
+)
 
 // ContainerChanges returns a list of container fs changes
 func (daemon *Daemon) ContainerChanges(name string) ([]archive.Change, error) {
@@ -13,3 +26,96 @@ func (daemon *Daemon) ContainerChanges(name string) ([]archive.Change, error) {
 	defer container.Unlock()
 	return daemon.changes(container)
 }
+
+// ContainerChangesDetails writes the container fs changes to the writer as a
+// stream of JSON objects, with the size, mode and sha256 of the files that
+// were added or modified. The contents of the files are read from the layer
+// of the container through its graphdriver.
+func (daemon *Daemon) ContainerChangesDetails(name string, out io.Writer) error {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+
+	container.Lock()
+	if err := daemon.Mount(container); err != nil {
+		container.Unlock()
+		return err
+	}
+	defer daemon.Unmount(container)
+	rootfs := container.BaseFS
+
+	changes, err := daemon.changes(container)
+	if err != nil {
+		container.Unlock()
+		return err
+	}
+	fg, err := container.RWLayer.DiffGetter()
+	container.Unlock()
+	if err != nil {
+		return err
+	}
+	defer fg.Close()
+
+	enc := json.NewEncoder(out)
+	for _, change := range changes {
+		c, err := changeDetails(rootfs, fg, change)
+		if err != nil {
+			return fmt.Errorf("Error getting the details of %s: %v", change.Path, err)
+		}
+		if err := enc.Encode(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// changeDetails returns the change with the size, mode and sha256 of the file
+// if it was added or modified. The file is looked up in rootfs without
+// following symlinks outside of it, and files removed since the changes were
+// computed are returned without details.
+func changeDetails(rootfs string, fg storage.FileGetter, change archive.Change) (types.ContainerChange, error) {
+	c := types.ContainerChange{
+		Kind: int(change.Kind),
+		Path: change.Path,
+	}
+	if change.Kind == archive.ChangeDelete {
+		return c, nil
+	}
+
+	dir := filepath.Join(rootfs, filepath.Dir(change.Path))
+	resolvedDir, err := symlink.FollowSymlinkInScope(dir, rootfs)
+	if err != nil {
+		return c, err
+	}
+	fi, err := os.Lstat(filepath.Join(resolvedDir, filepath.Base(change.Path)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return c, nil
+		}
+		return c, err
+	}
+	c.Size = fi.Size()
+	c.Mode = fi.Mode()
+
+	// Only hash regular files whose path does not go through a symlink, the
+	// file getter reads them by their path in the layer.
+	if !fi.Mode().IsRegular() || resolvedDir != dir {
+		return c, nil
+	}
+	f, err := fg.Get(change.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return c, nil
+		}
+		return c, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return c, err
+	}
+	c.Sha256 = hex.EncodeToString(h.Sum(nil))
+	return c, nil
+}
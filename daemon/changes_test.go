@@ -0,0 +1,67 @@
+package daemon
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/pkg/archive"
+	"github.com/vbatts/tar-split/tar/storage"
+)
+
+func TestChangeDetails(t *testing.T) {
+	rootfs, err := ioutil.TempDir("", "docker-changes-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootfs)
+
+	if err := ioutil.WriteFile(filepath.Join(rootfs, "file"), []byte("hello"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(rootfs, "dir"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// A symlink to a directory outside of the rootfs.
+	if err := os.Symlink("/etc", filepath.Join(rootfs, "link")); err != nil {
+		t.Fatal(err)
+	}
+	fg := storage.NewPathFileGetter(rootfs)
+
+	c, err := changeDetails(rootfs, fg, archive.Change{Path: "/file", Kind: archive.ChangeAdd})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Size != 5 || c.Mode != 0640 {
+		t.Fatalf("Expected size 5 and mode 0640, got %d and %o", c.Size, c.Mode)
+	}
+	if expected := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"; c.Sha256 != expected {
+		t.Fatalf("Expected sha256 %s, got %s", expected, c.Sha256)
+	}
+
+	c, err = changeDetails(rootfs, fg, archive.Change{Path: "/dir", Kind: archive.ChangeModify})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.Mode.IsDir() || c.Sha256 != "" {
+		t.Fatalf("Expected a directory without sha256, got mode %v and sha256 %q", c.Mode, c.Sha256)
+	}
+
+	// The file is looked up in the rootfs, not in the target of the symlink.
+	c, err = changeDetails(rootfs, fg, archive.Change{Path: "/link/passwd", Kind: archive.ChangeModify})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Mode != 0 || c.Sha256 != "" {
+		t.Fatalf("Expected no details for a file behind a symlink, got mode %v and sha256 %q", c.Mode, c.Sha256)
+	}
+
+	c, err = changeDetails(rootfs, fg, archive.Change{Path: "/file", Kind: archive.ChangeDelete})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Size != 0 || c.Mode != 0 || c.Sha256 != "" {
+		t.Fatalf("Expected no details for a deleted file, got %+v", c)
+	}
+}
@@ -3,6 +3,7 @@ package daemon
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"runtime"
 	"strings"
 	"time"
@@ -94,6 +95,9 @@ func merge(userConf, imageConf *containertypes.Config) error {
 	if userConf.StopSignal == "" {
 		userConf.StopSignal = imageConf.StopSignal
 	}
+	if userConf.StopTimeout == nil {
+		userConf.StopTimeout = imageConf.StopTimeout
+	}
 	return nil
 }
 
@@ -110,9 +114,10 @@ func (daemon *Daemon) Commit(name string, c *backend.ContainerCommitConfig) (str
 		return "", fmt.Errorf("Windows does not support commit of a running container")
 	}
 
-	if c.Pause && !container.IsPaused() {
-		daemon.containerPause(container)
-		defer daemon.containerUnpause(container)
+	// The files of the container are owned by IDs outside of the mapping of
+	// the layer store.
+	if container.HostConfig.UsernsMode.IsIsolated() {
+		return "", fmt.Errorf("Cannot commit a container running with an isolated user namespace")
 	}
 
 	newConfig, err := dockerfile.BuildFromConfig(c.Config, c.Changes)
@@ -126,15 +131,37 @@ func (daemon *Daemon) Commit(name string, c *backend.ContainerCommitConfig) (str
 		}
 	}
 
-	rwTar, err := daemon.exportContainerRw(container)
-	if err != nil {
-		return "", err
+	// A snapshot of the layer of a running container is consistent without
+	// pausing it, when the storage driver supports snapshots.
+	var rwTar io.ReadCloser
+	if container.IsRunning() {
+		rwTar, err = container.RWLayer.SnapshotTarStream()
+		if err != nil && err != layer.ErrSnapshotNotSupported {
+			return "", err
+		}
 	}
-	defer func() {
-		if rwTar != nil {
-			rwTar.Close()
+	if rwTar == nil {
+		if c.Pause && !container.IsPaused() {
+			daemon.containerPause(container)
+			defer daemon.containerUnpause(container)
 		}
-	}()
+
+		rwTar, err = daemon.exportContainerRw(container)
+		if err != nil {
+			return "", err
+		}
+	}
+	defer rwTar.Close()
+
+	var layerTar io.Reader = rwTar
+	if len(c.Exclude) > 0 {
+		excluded, err := archive.ExcludeLayerEntries(rwTar, commitExcludePatterns(c.Exclude))
+		if err != nil {
+			return "", err
+		}
+		defer excluded.Close()
+		layerTar = excluded
+	}
 
 	var history []image.History
 	rootFS := image.NewRootFS()
@@ -152,7 +179,7 @@ func (daemon *Daemon) Commit(name string, c *backend.ContainerCommitConfig) (str
 		osFeatures = img.OSFeatures
 	}
 
-	l, err := daemon.layerStore.Register(rwTar, rootFS.ChainID())
+	l, err := daemon.layerStore.Register(layerTar, rootFS.ChainID())
 	if err != nil {
 		return "", err
 	}
@@ -227,6 +254,21 @@ func (daemon *Daemon) Commit(name string, c *backend.ContainerCommitConfig) (str
 	return id.String(), nil
 }
 
+// commitExcludePatterns returns the exclusion patterns of a commit relative to
+// the root of the layer, paths in the container being absolute.
+func commitExcludePatterns(excludes []string) []string {
+	patterns := make([]string, len(excludes))
+	for i, pattern := range excludes {
+		pattern = strings.TrimSpace(pattern)
+		if strings.HasPrefix(pattern, "!") {
+			patterns[i] = "!" + strings.TrimLeft(pattern[1:], "/")
+		} else {
+			patterns[i] = strings.TrimLeft(pattern, "/")
+		}
+	}
+	return patterns
+}
+
 func (daemon *Daemon) exportContainerRw(container *container.Container) (archive.Archive, error) {
 	if err := daemon.Mount(container); err != nil {
 		return nil, err
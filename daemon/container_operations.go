@@ -581,6 +581,10 @@ func (daemon *Daemon) connectToNetwork(container *container.Container, idOrName
 		return fmt.Errorf("Updating join info failed: %v", err)
 	}
 
+	if err := setNetworkRate(container, sb, ep); err != nil {
+		return err
+	}
+
 	daemon.LogNetworkEventWithAttributes(n, "connect", map[string]string{"container": container.ID})
 	return nil
 }
@@ -35,6 +35,8 @@ func (daemon *Daemon) ContainerCreate(params types.ContainerCreateConfig) (types
 	if params.HostConfig == nil {
 		params.HostConfig = &containertypes.HostConfig{}
 	}
+	// The published ports are picked by the daemon once the container starts
+	params.HostConfig.PublishedPorts = nil
 	err = daemon.adaptContainerSettings(params.HostConfig, params.AdjustCPUShares)
 	if err != nil {
 		return types.ContainerCreateResponse{Warnings: warnings}, err
@@ -21,7 +21,15 @@ func (daemon *Daemon) createContainerPlatformSpecificSettings(container *contain
 	}
 	defer daemon.Unmount(container)
 
-	rootUID, rootGID := daemon.GetRemappedUIDGID()
+	if err := daemon.setupContainerIDMaps(container); err != nil {
+		return err
+	}
+
+	if container.HostConfig.Runtime == "" {
+		container.HostConfig.Runtime = daemon.configStore.GetDefaultRuntimeName()
+	}
+
+	rootUID, rootGID := daemon.getContainerRemappedUIDGID(container)
 	if err := container.SetupWorkingDirectory(rootUID, rootGID); err != nil {
 		return err
 	}
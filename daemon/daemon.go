@@ -121,6 +121,7 @@ type Daemon struct {
 	shutdown                  bool
 	uidMaps                   []idtools.IDMap
 	gidMaps                   []idtools.IDMap
+	idRanges                  *idRangeAllocator
 	layerStore                layer.Store
 	imageStore                image.Store
 	nameIndex                 *registrar.Registrar
@@ -277,6 +278,7 @@ func (daemon *Daemon) restore() error {
 
 	var migrateLegacyLinks bool
 	restartContainers := make(map[*container.Container]chan struct{})
+	removeContainers := make(map[string]*container.Container)
 	for _, c := range containers {
 		if err := daemon.registerName(c); err != nil {
 			logrus.Errorf("Failed to register container %s: %s", c.ID, err)
@@ -286,6 +288,7 @@ func (daemon *Daemon) restore() error {
 			logrus.Errorf("Failed to register container %s: %s", c.ID, err)
 			continue
 		}
+		daemon.restoreContainerIDMaps(c)
 	}
 	var wg sync.WaitGroup
 	var mapLock sync.Mutex
@@ -300,7 +303,13 @@ func (daemon *Daemon) restore() error {
 					logrus.Errorf("Failed to ReinitRWLayer for %s due to %s", c.ID, err)
 					return
 				}
-				if err := daemon.containerd.Restore(c.ID, libcontainerd.WithRestartManager(rm)); err != nil {
+				options, err := daemon.getLibcontainerdCreateOptions(c)
+				if err != nil {
+					logrus.Errorf("Failed to restore container %s: %v", c.ID, err)
+					return
+				}
+				options = append(options, libcontainerd.WithRestartManager(rm))
+				if err := daemon.containerd.Restore(c.ID, options...); err != nil {
 					logrus.Errorf("Failed to restore with containerd: %q", err)
 					return
 				}
@@ -311,6 +320,11 @@ func (daemon *Daemon) restore() error {
 				mapLock.Lock()
 				restartContainers[c] = make(chan struct{})
 				mapLock.Unlock()
+			} else if !c.IsRunning() && !c.IsPaused() && c.HostConfig != nil && c.HostConfig.AutoRemove {
+				// the container exited while the daemon was down
+				mapLock.Lock()
+				removeContainers[c.ID] = c
+				mapLock.Unlock()
 			}
 
 			// if c.hostConfig.Links is nil (not just empty), then it is using the old sqlite links and needs to be migrated
@@ -374,6 +388,19 @@ func (daemon *Daemon) restore() error {
 	}
 	group.Wait()
 
+	// remove the containers created with AutoRemove which are not running
+	// anymore, since no one will remove them.
+	for id := range removeContainers {
+		group.Add(1)
+		go func(cid string) {
+			defer group.Done()
+			if err := daemon.ContainerRm(cid, &types.ContainerRmConfig{ForceRemove: true, RemoveVolume: true}); err != nil {
+				logrus.Errorf("Failed to remove container %s: %v", cid, err)
+			}
+		}(id)
+	}
+	group.Wait()
+
 	// any containers that were started above would already have had this done,
 	// however we need to now prepare the mountpoints for the rest of the containers as well.
 	// This shouldn't cause any issue running on the containers that already had this run.
@@ -387,6 +414,9 @@ func (daemon *Daemon) restore() error {
 		if _, ok := restartContainers[c]; ok {
 			continue
 		}
+		if _, ok := removeContainers[c.ID]; ok {
+			continue
+		}
 		group.Add(1)
 		go func(c *container.Container) {
 			defer group.Done()
@@ -621,6 +651,10 @@ func NewDaemon(config *Config, registryService *registry.Service, containerdRemo
 	if err != nil {
 		return nil, err
 	}
+	uidMaps, gidMaps, idRanges, err := setupUsernsIsolation(config, uidMaps, gidMaps)
+	if err != nil {
+		return nil, err
+	}
 	rootUID, rootGID, err := idtools.GetRootUIDGID(uidMaps, gidMaps)
 	if err != nil {
 		return nil, err
@@ -799,6 +833,7 @@ func NewDaemon(config *Config, registryService *registry.Service, containerdRemo
 	d.root = config.Root
 	d.uidMaps = uidMaps
 	d.gidMaps = gidMaps
+	d.idRanges = idRanges
 	d.seccompEnabled = sysInfo.Seccomp
 
 	d.nameIndex = registrar.NewRegistrar()
@@ -819,6 +854,7 @@ func NewDaemon(config *Config, registryService *registry.Service, containerdRemo
 }
 
 func (daemon *Daemon) shutdownContainer(c *container.Container) error {
+	stopTimeout := c.StopTimeout()
 	// TODO(windows): Handle docker restart with paused containers
 	if c.IsPaused() {
 		// To terminate a process in freezer cgroup, we should send
@@ -835,8 +871,8 @@ func (daemon *Daemon) shutdownContainer(c *container.Container) error {
 		if err := daemon.containerUnpause(c); err != nil {
 			return fmt.Errorf("Failed to unpause container %s with error: %v", c.ID, err)
 		}
-		if _, err := c.WaitStop(10 * time.Second); err != nil {
-			logrus.Debugf("container %s failed to exit in 10 second of SIGTERM, sending SIGKILL to force", c.ID)
+		if _, err := c.WaitStop(time.Duration(stopTimeout) * time.Second); err != nil {
+			logrus.Debugf("container %s failed to exit in %d second of SIGTERM, sending SIGKILL to force", c.ID, stopTimeout)
 			sig, ok := signal.SignalMap["KILL"]
 			if !ok {
 				return fmt.Errorf("System does not support SIGKILL")
@@ -848,8 +884,8 @@ func (daemon *Daemon) shutdownContainer(c *container.Container) error {
 			return err
 		}
 	}
-	// If container failed to exit in 10 seconds of SIGTERM, then using the force
-	if err := daemon.containerStop(c, 10); err != nil {
+	// If container failed to exit in stopTimeout seconds of SIGTERM, then using the force
+	if err := daemon.containerStop(c, stopTimeout); err != nil {
 		return fmt.Errorf("Stop container %s with error: %v", c.ID, err)
 	}
 
@@ -857,6 +893,32 @@ func (daemon *Daemon) shutdownContainer(c *container.Container) error {
 	return nil
 }
 
+// ShutdownTimeout returns the timeout (in seconds) of the shutdown of the
+// daemon, based on the longest stop timeout of the running containers. A
+// negative value means that the shutdown waits for the containers forever.
+func (daemon *Daemon) ShutdownTimeout() int {
+	// By default, containers are given container.DefaultStopTimeout to
+	// stop, and the daemon 5 more seconds to clean up.
+	graceTimeout := 5
+	shutdownTimeout := container.DefaultStopTimeout + graceTimeout
+	if daemon.containers == nil {
+		return shutdownTimeout
+	}
+	for _, c := range daemon.containers.List() {
+		if !c.IsRunning() {
+			continue
+		}
+		stopTimeout := c.StopTimeout()
+		if stopTimeout < 0 {
+			return -1
+		}
+		if stopTimeout+graceTimeout > shutdownTimeout {
+			shutdownTimeout = stopTimeout + graceTimeout
+		}
+	}
+	return shutdownTimeout
+}
+
 // Shutdown stops the daemon.
 func (daemon *Daemon) Shutdown() error {
 	daemon.shutdown = true
@@ -977,11 +1039,13 @@ func isBrokenPipe(e error) bool {
 // ExportImage exports a list of images to the given output stream. The
 // exported images are archived into a tar when written to the output
 // stream. All images with the given tag and all versions containing
-// the same tag are exported. names is the set of tags to export, and
-// outStream is the writer which the images are written to.
-func (daemon *Daemon) ExportImage(names []string, outStream io.Writer) error {
+// the same tag are exported. names is the set of tags to export,
+// outStream is the writer which the images are written to, and format is
+// the format of the archive, either "docker" (the default) or "oci" for an
+// OCI image layout.
+func (daemon *Daemon) ExportImage(names []string, outStream io.Writer, format string) error {
 	imageExporter := tarexport.NewTarExporter(daemon.imageStore, daemon.layerStore, daemon.referenceStore)
-	return imageExporter.Save(names, outStream)
+	return imageExporter.Save(names, outStream, format)
 }
 
 // LookupImage looks up an image by name and returns it as an ImageInspect
@@ -1343,6 +1407,10 @@ func (daemon *Daemon) verifyContainerSettings(hostConfig *containertypes.HostCon
 		return nil, err
 	}
 
+	if hostConfig.AutoRemove && hostConfig.RestartPolicy.Name != "" && !hostConfig.RestartPolicy.IsNone() {
+		return nil, fmt.Errorf("Can't create 'AutoRemove' container with restart policy")
+	}
+
 	for port := range hostConfig.PortBindings {
 		_, portStr := nat.SplitProtoPort(string(port))
 		if _, err := nat.ParsePort(portStr); err != nil {
@@ -1589,7 +1657,7 @@ func (daemon *Daemon) networkOptions(dconfig *Config) ([]nwconfig.Option, error)
 	options = append(options, nwconfig.OptionDefaultDriver(string(dd)))
 	options = append(options, nwconfig.OptionDefaultNetwork(dn))
 
-	if strings.TrimSpace(dconfig.ClusterStore) != "" {
+	if strings.TrimSpace(dconfig.ClusterStore) != "" && !isDiscoveryOnlyBackend(dconfig.ClusterStore) {
 		kv := strings.Split(dconfig.ClusterStore, "://")
 		if len(kv) != 2 {
 			return nil, fmt.Errorf("kv store daemon config must be of the form KV-PROVIDER://KV-URL")
@@ -5,6 +5,7 @@ package daemon
 import (
 	"fmt"
 	"io/ioutil"
+	"math"
 	"net"
 	"os"
 	"path/filepath"
@@ -29,12 +30,14 @@ import (
 	"github.com/docker/engine-api/types"
 	pblkiodev "github.com/docker/engine-api/types/blkiodev"
 	containertypes "github.com/docker/engine-api/types/container"
+	"github.com/docker/go-connections/nat"
 	"github.com/docker/libnetwork"
 	nwconfig "github.com/docker/libnetwork/config"
 	"github.com/docker/libnetwork/drivers/bridge"
 	"github.com/docker/libnetwork/ipamutils"
 	"github.com/docker/libnetwork/netlabel"
 	"github.com/docker/libnetwork/options"
+	"github.com/docker/libnetwork/portallocator"
 	lntypes "github.com/docker/libnetwork/types"
 	"github.com/opencontainers/runc/libcontainer/label"
 	"github.com/opencontainers/runc/libcontainer/user"
@@ -55,6 +58,16 @@ const (
 	// constant for cgroup drivers
 	cgroupFsDriver      = "cgroupfs"
 	cgroupSystemdDriver = "systemd"
+
+	// DefaultRuntimeBinary is the default runtime to be used by
+	// containerd if none is specified
+	DefaultRuntimeBinary = "docker-runc"
+
+	// stockRuntimeName is the name of the runtime shipped with docker
+	stockRuntimeName = "runc"
+
+	// DefaultInitBinary is the name of the default init binary
+	DefaultInitBinary = "docker-init"
 )
 
 func getMemoryResources(config containertypes.Resources) *specs.Memory {
@@ -116,9 +129,24 @@ func getCPUResources(config containertypes.Resources) *specs.CPU {
 		cpu.Quota = &quota
 	}
 
+	if config.NanoCPUs > 0 {
+		period, quota := nanoCPUsToCFS(config.NanoCPUs)
+		cpu.Period = &period
+		cpu.Quota = &quota
+	}
+
 	return &cpu
 }
 
+// nanoCPUsToCFS converts a number of CPUs, in units of 10^-9 CPUs, into a
+// CFS period and quota (in microseconds). The period is the default of the
+// kernel, 100ms.
+func nanoCPUsToCFS(nanoCPUs int64) (period uint64, quota uint64) {
+	period = uint64(100 * time.Millisecond / time.Microsecond)
+	quota = uint64(nanoCPUs) * period / 1e9
+	return period, quota
+}
+
 func getBlkioWeightDevices(config containertypes.Resources) ([]specs.WeightDevice, error) {
 	var stat syscall.Stat_t
 	var blkioWeightDevices []specs.WeightDevice
@@ -320,6 +348,18 @@ func (daemon *Daemon) adaptContainerSettings(hostConfig *containertypes.HostConf
 func verifyContainerResources(resources *containertypes.Resources, sysInfo *sysinfo.SysInfo, update bool) ([]string, error) {
 	warnings := []string{}
 
+	// devices cgroup checks
+	for _, rule := range resources.DeviceCgroupRules {
+		if _, err := runconfigopts.ValidateDeviceCgroupRule(rule); err != nil {
+			return warnings, err
+		}
+	}
+
+	// network rate checks
+	if resources.NetworkIngressRate > math.MaxUint32 || resources.NetworkEgressRate > math.MaxUint32 {
+		return warnings, fmt.Errorf("Maximum network rate allowed is 4GB per second")
+	}
+
 	// memory subsystem checks and adjustments
 	if resources.Memory != 0 && resources.Memory < linuxMinMemory {
 		return warnings, fmt.Errorf("Minimum memory limit allowed is 4MB")
@@ -413,6 +453,20 @@ func verifyContainerResources(resources *containertypes.Resources, sysInfo *sysi
 	if resources.CPUQuota > 0 && resources.CPUQuota < 1000 {
 		return warnings, fmt.Errorf("CPU cfs quota can not be less than 1ms (i.e. 1000)")
 	}
+	if resources.NanoCPUs > 0 && resources.CPUPeriod > 0 {
+		return warnings, fmt.Errorf("Conflicting options: Nano CPUs and CPU Period cannot both be set")
+	}
+	if resources.NanoCPUs > 0 && resources.CPUQuota > 0 {
+		return warnings, fmt.Errorf("Conflicting options: Nano CPUs and CPU Quota cannot both be set")
+	}
+	if resources.NanoCPUs > 0 && (!sysInfo.CPUCfsPeriod || !sysInfo.CPUCfsQuota) {
+		return warnings, fmt.Errorf("NanoCPUs can not be set, as your kernel does not support CPU cfs period/quota or the cgroup is not mounted")
+	}
+	// With the default period of 100ms, the quota can not be less than 1ms,
+	// so the lowest limit is 0.01 CPU.
+	if resources.NanoCPUs < 0 || (resources.NanoCPUs > 0 && resources.NanoCPUs < 1e7) || resources.NanoCPUs > int64(runtime.NumCPU())*1e9 {
+		return warnings, fmt.Errorf("Range of CPUs is from 0.01 to %d.00, as there are only %d CPUs available", runtime.NumCPU(), runtime.NumCPU())
+	}
 	if resources.CPUPercent > 0 {
 		warnings = append(warnings, "%s does not support CPU percent. Percent discarded.", runtime.GOOS)
 		logrus.Warnf("%s does not support CPU percent. Percent discarded.", runtime.GOOS)
@@ -535,6 +589,11 @@ func verifyPlatformContainerSettings(daemon *Daemon, hostConfig *containertypes.
 		return warnings, fmt.Errorf("SHM size must be greater than 0")
 	}
 
+	if (hostConfig.NetworkIngressRate != 0 || hostConfig.NetworkEgressRate != 0) &&
+		(hostConfig.NetworkMode.IsHost() || hostConfig.NetworkMode.IsContainer()) {
+		return warnings, fmt.Errorf("Conflicting options: network rate and the network mode (--net)")
+	}
+
 	if hostConfig.OomScoreAdj < -1000 || hostConfig.OomScoreAdj > 1000 {
 		return warnings, fmt.Errorf("Invalid value %d, range for oom score adj is [-1000, 1000]", hostConfig.OomScoreAdj)
 	}
@@ -542,6 +601,12 @@ func verifyPlatformContainerSettings(daemon *Daemon, hostConfig *containertypes.
 		warnings = append(warnings, "IPv4 forwarding is disabled. Networking will not work.")
 		logrus.Warnf("IPv4 forwarding is disabled. Networking will not work")
 	}
+	if hostConfig.Runtime != "" && daemon.configStore.GetRuntime(hostConfig.Runtime) == nil {
+		return warnings, fmt.Errorf("Unknown runtime specified %s", hostConfig.Runtime)
+	}
+	if hostConfig.UsernsMode.IsIsolated() && daemon.idRanges == nil {
+		return warnings, fmt.Errorf("Isolated user namespaces require the daemon to run with --userns-isolation")
+	}
 	// check for various conflicting options with user namespaces
 	if daemon.configStore.RemappedRoot != "" && hostConfig.UsernsMode.IsPrivate() {
 		if hostConfig.Privileged {
@@ -578,6 +643,11 @@ func verifyDaemonSettings(config *Config) error {
 	if !config.bridgeConfig.EnableIPTables && config.bridgeConfig.EnableIPMasq {
 		config.bridgeConfig.EnableIPMasq = false
 	}
+	if config.bridgeConfig.EphemeralPortRange != "" {
+		if _, _, err := nat.ParsePortRange(config.bridgeConfig.EphemeralPortRange); err != nil {
+			return fmt.Errorf("invalid ephemeral port range %s: %v", config.bridgeConfig.EphemeralPortRange, err)
+		}
+	}
 	if err := VerifyCgroupDriver(config); err != nil {
 		return err
 	}
@@ -586,6 +656,20 @@ func verifyDaemonSettings(config *Config) error {
 			return fmt.Errorf("cgroup-parent for systemd cgroup should be a valid slice named as \"xxx.slice\"")
 		}
 	}
+
+	if config.Runtimes == nil {
+		config.Runtimes = make(map[string]types.Runtime)
+	}
+	if _, ok := config.Runtimes[stockRuntimeName]; ok {
+		return fmt.Errorf("runtime name '%s' is reserved", stockRuntimeName)
+	}
+	config.Runtimes[stockRuntimeName] = types.Runtime{Path: DefaultRuntimeBinary}
+	if config.DefaultRuntime == "" {
+		config.DefaultRuntime = stockRuntimeName
+	}
+	if config.GetRuntime(config.DefaultRuntime) == nil {
+		return fmt.Errorf("specified default runtime '%s' does not exist", config.DefaultRuntime)
+	}
 	return nil
 }
 
@@ -638,6 +722,16 @@ func (daemon *Daemon) initNetworkController(config *Config) (libnetwork.NetworkC
 		return nil, err
 	}
 
+	if config.bridgeConfig.EphemeralPortRange != "" {
+		start, end, err := nat.ParsePortRange(config.bridgeConfig.EphemeralPortRange)
+		if err != nil {
+			return nil, err
+		}
+		if err := portallocator.Get().SetPortRange(int(start), int(end)); err != nil {
+			return nil, err
+		}
+	}
+
 	controller, err := libnetwork.New(netOptions...)
 	if err != nil {
 		return nil, fmt.Errorf("error obtaining controller instance: %v", err)
@@ -90,6 +90,22 @@ func TestAdjustCPUSharesNoAdjustment(t *testing.T) {
 }
 
 // Unix test as uses settings which are not available on Windows
+func TestNanoCPUsToCFS(t *testing.T) {
+	for nanoCPUs, expectedQuota := range map[int64]uint64{
+		1e9:  100000,
+		15e8: 150000,
+		1e7:  1000,
+	} {
+		period, quota := nanoCPUsToCFS(nanoCPUs)
+		if period != 100000 {
+			t.Fatalf("Expected a period of 100000, got %d", period)
+		}
+		if quota != expectedQuota {
+			t.Fatalf("Expected a quota of %d for %d nano CPUs, got %d", expectedQuota, nanoCPUs, quota)
+		}
+	}
+}
+
 func TestParseSecurityOptWithDeprecatedColon(t *testing.T) {
 	container := &container.Container{}
 	config := &containertypes.HostConfig{}
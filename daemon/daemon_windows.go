@@ -107,6 +107,14 @@ func verifyContainerResources(resources *containertypes.Resources, sysInfo *sysi
 		return warnings, fmt.Errorf("Conflicting options: CPU Shares and CPU Percent cannot both be set")
 	}
 
+	if resources.NanoCPUs > 0 {
+		return warnings, fmt.Errorf("NanoCPUs is not supported on Windows, use CPU percent instead")
+	}
+
+	if resources.NetworkIngressRate != 0 || resources.NetworkEgressRate != 0 {
+		return warnings, fmt.Errorf("Network rate is not supported on Windows")
+	}
+
 	return warnings, nil
 }
 
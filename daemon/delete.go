@@ -113,6 +113,7 @@ func (daemon *Daemon) cleanupContainer(container *container.Container, forceRemo
 			daemon.nameIndex.Delete(container.ID)
 			daemon.linkIndex.delete(container)
 			selinuxFreeLxcContexts(container.ProcessLabel)
+			daemon.releaseContainerIDMaps(container)
 			daemon.idIndex.Delete(container.ID)
 			daemon.containers.Delete(container.ID)
 			daemon.LogContainerEvent(container, "destroy")
@@ -5,11 +5,15 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/docker/docker/pkg/discovery"
 
+	// Register the DNS SRV and HTTP backends for discovery.
+	_ "github.com/docker/docker/pkg/discovery/dns"
+	_ "github.com/docker/docker/pkg/discovery/http"
 	// Register the libkv backends for discovery.
 	_ "github.com/docker/docker/pkg/discovery/kv"
 )
@@ -23,6 +27,20 @@ const (
 
 var errDiscoveryDisabled = errors.New("discovery is disabled")
 
+// discoveryOnlyBackends are the discovery backends which only list the nodes
+// of the cluster, and cannot be used as a key-value store by libnetwork.
+var discoveryOnlyBackends = map[string]bool{
+	"dns":   true,
+	"http":  true,
+	"https": true,
+}
+
+// isDiscoveryOnlyBackend returns whether the cluster store only provides
+// nodes discovery.
+func isDiscoveryOnlyBackend(clusterStore string) bool {
+	return discoveryOnlyBackends[strings.SplitN(clusterStore, "://", 2)[0]]
+}
+
 type discoveryReloader interface {
 	discovery.Watcher
 	Stop()
@@ -101,6 +119,11 @@ func (d *daemonDiscoveryReloader) advertise(address string) {
 
 func (d *daemonDiscoveryReloader) registerAddr(addr string) {
 	if err := d.backend.Register(addr); err != nil {
+		if err == discovery.ErrNotImplemented {
+			// The backend lists nodes registered by other means.
+			log.Debugf("Discovery backend does not support registering %q", addr)
+			return
+		}
 		log.Warnf("Registering as %q in discovery failed: %v", addr, err)
 	}
 }
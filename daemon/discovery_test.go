@@ -150,3 +150,18 @@ func discoveryConfig(backendAddr, advertiseAddr string, opts map[string]string)
 		},
 	}
 }
+
+func TestIsDiscoveryOnlyBackend(t *testing.T) {
+	cases := map[string]bool{
+		"dns://_docker._tcp.example.com": true,
+		"https://example.com/nodes":      true,
+		"consul://127.0.0.1:8500":        false,
+		"memory://127.0.0.1":             false,
+		"127.0.0.1:2222":                 false,
+	}
+	for store, expected := range cases {
+		if isDiscoveryOnlyBackend(store) != expected {
+			t.Fatalf("expected %v for %s, got %v", expected, store, !expected)
+		}
+	}
+}
@@ -3,7 +3,10 @@ package daemon
 import (
 	"fmt"
 	"io"
+	"path/filepath"
+	"runtime"
 	"strings"
+	"syscall"
 	"time"
 
 	"golang.org/x/net/context"
@@ -14,7 +17,10 @@ import (
 	"github.com/docker/docker/errors"
 	"github.com/docker/docker/libcontainerd"
 	"github.com/docker/docker/pkg/pools"
+	"github.com/docker/docker/pkg/signal"
+	"github.com/docker/docker/pkg/system"
 	"github.com/docker/docker/pkg/term"
+	"github.com/docker/docker/utils"
 	"github.com/docker/engine-api/types"
 	"github.com/docker/engine-api/types/strslice"
 )
@@ -64,9 +70,21 @@ func (d *Daemon) getExecConfig(name string) (*exec.Config, error) {
 	return nil, errExecNotFound(name)
 }
 
-func (d *Daemon) unregisterExecCommand(container *container.Container, execConfig *exec.Config) {
-	container.ExecCommands.Delete(execConfig.ID)
-	d.execCommands.Delete(execConfig.ID)
+// exitExecCommands removes the execs of a stopped container from it. Their
+// processes died along with the container, so the ones still running are
+// marked as killed. They are kept in the daemon, so that their exit status
+// can be inspected until execCommandGC cleans them up.
+func (d *Daemon) exitExecCommands(container *container.Container) {
+	for _, execConfig := range container.ExecCommands.Commands() {
+		execConfig.Lock()
+		if execConfig.Running {
+			exitCode := 128 + int(syscall.SIGKILL)
+			execConfig.ExitCode = &exitCode
+			execConfig.Running = false
+		}
+		execConfig.Unlock()
+		container.ExecCommands.Delete(execConfig.ID)
+	}
 }
 
 func (d *Daemon) getActiveContainer(name string) (*container.Container, error) {
@@ -120,6 +138,23 @@ func (d *Daemon) ContainerExecCreate(name string, config *types.ExecConfig) (str
 	if len(execConfig.User) == 0 {
 		execConfig.User = container.Config.User
 	}
+	if len(config.Env) > 0 {
+		linkedEnv, err := d.setupLinkedContainers(container)
+		if err != nil {
+			return "", err
+		}
+		execConfig.Env = utils.ReplaceOrAppendEnvValues(container.CreateDaemonEnvironment(linkedEnv), config.Env)
+	}
+	if config.WorkingDir != "" {
+		execConfig.WorkingDir = filepath.FromSlash(config.WorkingDir)
+		if !system.IsAbs(execConfig.WorkingDir) {
+			return "", fmt.Errorf("The working directory '%s' is invalid. It needs to be an absolute path", config.WorkingDir)
+		}
+	}
+	if config.Timeout < 0 {
+		return "", fmt.Errorf("Invalid timeout %d, it must be a positive number of seconds", config.Timeout)
+	}
+	execConfig.Timeout = config.Timeout
 
 	d.registerExecCommand(container, execConfig)
 
@@ -190,6 +225,7 @@ func (d *Daemon) ContainerExecStart(name string, stdin io.ReadCloser, stdout io.
 
 	p := libcontainerd.Process{
 		Args:     append([]string{ec.Entrypoint}, ec.Args...),
+		Env:      ec.Env,
 		Terminal: ec.Tty,
 	}
 
@@ -203,6 +239,12 @@ func (d *Daemon) ContainerExecStart(name string, stdin io.ReadCloser, stdout io.
 		return err
 	}
 
+	if ec.Timeout > 0 {
+		time.AfterFunc(time.Duration(ec.Timeout)*time.Second, func() {
+			d.killTimedOutExec(ec)
+		})
+	}
+
 	err = <-attachErr
 	if err != nil {
 		return fmt.Errorf("attach failed with error: %v", err)
@@ -210,6 +252,49 @@ func (d *Daemon) ContainerExecStart(name string, stdin io.ReadCloser, stdout io.
 	return nil
 }
 
+// ContainerExecKill sends a signal to the process of a running exec. If no
+// signal is given (sig 0), the process is killed with SIGKILL.
+func (d *Daemon) ContainerExecKill(name string, sig uint64) error {
+	ec, err := d.getExecConfig(name)
+	if err != nil {
+		return err
+	}
+
+	if sig == 0 {
+		sig = uint64(syscall.SIGKILL)
+	}
+	if !signal.ValidSignalForPlatform(syscall.Signal(sig)) {
+		return fmt.Errorf("The %s daemon does not support signal %d", runtime.GOOS, sig)
+	}
+
+	ec.Lock()
+	running := ec.Running
+	ec.Unlock()
+	if !running {
+		err := fmt.Errorf("Exec %s is not running", ec.ID)
+		return errors.NewRequestConflictError(err)
+	}
+
+	return d.containerd.SignalProcess(ec.ContainerID, ec.ID, int(sig))
+}
+
+// killTimedOutExec kills the process of an exec whose timeout expired, if it
+// is still running.
+func (d *Daemon) killTimedOutExec(ec *exec.Config) {
+	ec.Lock()
+	if !ec.Running {
+		ec.Unlock()
+		return
+	}
+	ec.TimedOut = true
+	ec.Unlock()
+
+	logrus.Debugf("killing exec command %s in container %s after its timeout of %d seconds", ec.ID, ec.ContainerID, ec.Timeout)
+	if err := d.containerd.SignalProcess(ec.ContainerID, ec.ID, int(syscall.SIGKILL)); err != nil {
+		logrus.Warnf("failed to kill exec command %s after its timeout: %v", ec.ID, err)
+	}
+}
+
 // execCommandGC runs a ticker to clean up the daemon references
 // of exec configs that are no longer part of the container.
 func (d *Daemon) execCommandGC() {
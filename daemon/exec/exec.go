@@ -27,6 +27,13 @@ type Config struct {
 	Tty         bool
 	Privileged  bool
 	User        string
+	Env         []string
+	WorkingDir  string
+	// Timeout is the number of seconds after which the process is killed,
+	// 0 for no timeout.
+	Timeout int
+	// TimedOut is set when the process was killed by its timeout.
+	TimedOut bool
 }
 
 // NewConfig initializes the a new exec configuration
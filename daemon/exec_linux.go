@@ -22,5 +22,8 @@ func execSetPlatformOpt(c *container.Container, ec *exec.Config, p *libcontainer
 	if ec.Privileged {
 		p.Capabilities = caps.GetAllCapabilities()
 	}
+	if ec.WorkingDir != "" {
+		p.Cwd = &ec.WorkingDir
+	}
 	return nil
 }
@@ -9,5 +9,8 @@ import (
 func execSetPlatformOpt(c *container.Container, ec *exec.Config, p *libcontainerd.Process) error {
 	// Process arguments need to be escaped before sending to OCI.
 	p.Args = escapeArgs(p.Args)
+	if ec.WorkingDir != "" {
+		p.Cwd = ec.WorkingDir
+	}
 	return nil
 }
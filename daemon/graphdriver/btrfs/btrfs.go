@@ -296,6 +296,12 @@ func (d *Driver) Create(id, parent, mountLabel string, storageOpt map[string]str
 	return label.Relabel(path.Join(subvolumes, id), mountLabel, false)
 }
 
+// Snapshot creates the filesystem with given id as a snapshot of the parent.
+// Subvolume snapshots are atomic, the parent can be in use.
+func (d *Driver) Snapshot(id, parent string) error {
+	return d.Create(id, parent, "", nil)
+}
+
 // Remove the filesystem with given id.
 func (d *Driver) Remove(id string) error {
 	dir := d.subvolumesDirID(id)
@@ -132,6 +132,13 @@ func (d *Driver) Create(id, parent, mountLabel string, storageOpt map[string]str
 	return nil
 }
 
+// Snapshot adds a device with a given id as a thin snapshot of the parent.
+// The parent device is suspended while the snapshot is taken, it can be in
+// use.
+func (d *Driver) Snapshot(id, parent string) error {
+	return d.Create(id, parent, "", nil)
+}
+
 // Remove removes a device with a given id, unmounts the filesystem.
 func (d *Driver) Remove(id string) error {
 	if !d.DeviceSet.HasDevice(id) {
@@ -113,6 +113,27 @@ type FileGetCloser interface {
 	Close() error
 }
 
+// Snapshotter is the interface for drivers which create the layers as
+// copy-on-write snapshots of their parent, which are consistent even while the
+// parent is mounted and written to.
+type Snapshotter interface {
+	// Snapshot creates the layer id as a snapshot of the layer parent.
+	Snapshot(id, parent string) error
+}
+
+// GetSnapshotter returns the Snapshotter of the driver, and false if the
+// driver does not support snapshots.
+func GetSnapshotter(driver Driver) (Snapshotter, bool) {
+	if s, ok := driver.(Snapshotter); ok {
+		return s, true
+	}
+	if naive, ok := driver.(*NaiveDiffDriver); ok {
+		s, ok := naive.ProtoDriver.(Snapshotter)
+		return s, ok
+	}
+	return nil, false
+}
+
 func init() {
 	drivers = make(map[string]InitFunc)
 }
@@ -274,6 +274,12 @@ func (d *Driver) Create(id string, parent string, mountLabel string, storageOpt
 	return d.create(id, parent)
 }
 
+// Snapshot creates the dataset for the given id as a clone of a snapshot of
+// the parent. ZFS snapshots are atomic, the parent can be in use.
+func (d *Driver) Snapshot(id, parent string) error {
+	return d.Create(id, parent, "", nil)
+}
+
 func (d *Driver) create(id, parent string) error {
 	name := d.zfsPath(id)
 	if parent == "" {
@@ -132,6 +132,7 @@ func (daemon *Daemon) SystemInfo() (*types.Info, error) {
 		v.CPUShares = sysInfo.CPUShares
 		v.CPUSet = sysInfo.Cpuset
 	}
+	daemon.fillPlatformInfo(v)
 
 	if hostname, err := os.Hostname(); err == nil {
 		v.Name = hostname
@@ -0,0 +1,11 @@
+// +build !windows
+
+package daemon
+
+import "github.com/docker/engine-api/types"
+
+// fillPlatformInfo fills the platform specific fields of the system info.
+func (daemon *Daemon) fillPlatformInfo(v *types.Info) {
+	v.Runtimes = daemon.configStore.Runtimes
+	v.DefaultRuntime = daemon.configStore.GetDefaultRuntimeName()
+}
@@ -0,0 +1,6 @@
+package daemon
+
+import "github.com/docker/engine-api/types"
+
+func (daemon *Daemon) fillPlatformInfo(v *types.Info) {
+}
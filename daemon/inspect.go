@@ -176,9 +176,11 @@ func (daemon *Daemon) getInspectData(container *container.Container, size bool)
 // ContainerExecInspect returns low-level information about the exec
 // command. An error is returned if the exec cannot be found.
 func (daemon *Daemon) ContainerExecInspect(id string) (*backend.ExecInspect, error) {
-	e, err := daemon.getExecConfig(id)
-	if err != nil {
-		return nil, err
+	// Execs are inspected even once their container stopped, so that their
+	// exit status is available until they are cleaned up.
+	e := daemon.execCommands.Get(id)
+	if e == nil || daemon.containers.Get(e.ContainerID) == nil {
+		return nil, errExecNotFound(id)
 	}
 
 	pc := inspectExecProcessConfig(e)
@@ -194,6 +196,8 @@ func (daemon *Daemon) ContainerExecInspect(id string) (*backend.ExecInspect, err
 		CanRemove:     e.CanRemove,
 		ContainerID:   e.ContainerID,
 		DetachKeys:    e.DetachKeys,
+		Timeout:       e.Timeout,
+		TimedOut:      e.TimedOut,
 	}, nil
 }
 
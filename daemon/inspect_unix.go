@@ -87,5 +87,6 @@ func inspectExecProcessConfig(e *exec.Config) *backend.ExecProcessConfig {
 		Arguments:  e.Args,
 		Privileged: &e.Privileged,
 		User:       e.User,
+		WorkingDir: e.WorkingDir,
 	}
 }
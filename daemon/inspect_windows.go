@@ -36,5 +36,6 @@ func inspectExecProcessConfig(e *exec.Config) *backend.ExecProcessConfig {
 		Tty:        e.Tty,
 		Entrypoint: e.Entrypoint,
 		Arguments:  e.Args,
+		WorkingDir: e.WorkingDir,
 	}
 }
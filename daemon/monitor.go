@@ -8,8 +8,10 @@ import (
 	"strconv"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/container"
 	"github.com/docker/docker/libcontainerd"
 	"github.com/docker/docker/runconfig"
+	"github.com/docker/engine-api/types"
 )
 
 // StateChanged updates daemon state changes from containerd
@@ -27,6 +29,9 @@ func (daemon *Daemon) StateChanged(id string, e libcontainerd.StateInfo) error {
 		}
 		daemon.LogContainerEvent(c, "oom")
 	case libcontainerd.StateExit:
+		// The container is removed once its state is saved and the lock
+		// released, since removing it needs the lock as well.
+		defer daemon.autoRemove(c)
 		c.Lock()
 		defer c.Unlock()
 		c.Wait()
@@ -148,3 +153,17 @@ func (daemon *Daemon) AttachStreams(id string, iop libcontainerd.IOPipe) error {
 
 	return nil
 }
+
+// autoRemove removes a stopped container along with its anonymous volumes if
+// it was created with AutoRemove, as `docker run --rm` does.
+func (daemon *Daemon) autoRemove(c *container.Container) {
+	c.Lock()
+	ar := c.HostConfig != nil && c.HostConfig.AutoRemove
+	c.Unlock()
+	if !ar {
+		return
+	}
+	if err := daemon.ContainerRm(c.ID, &types.ContainerRmConfig{ForceRemove: true, RemoveVolume: true}); err != nil {
+		logrus.Errorf("Failed to remove container %s after it exited: %v", c.ID, err)
+	}
+}
@@ -0,0 +1,149 @@
+package daemon
+
+import (
+	"fmt"
+	"net"
+	"runtime"
+
+	"github.com/docker/docker/container"
+	"github.com/docker/libnetwork"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+)
+
+const (
+	// tbfMinBurst is the minimum size of the bucket of the tbf qdiscs, large
+	// enough for the largest packets.
+	tbfMinBurst = 32 * 1024
+	// tbfBurstDivisor sizes the bucket of the tbf qdiscs to the traffic of
+	// 10ms at the rate.
+	tbfBurstDivisor = 100
+	// tbfLatencyDivisor sizes the queue of the tbf qdiscs to the traffic of
+	// 50ms at the rate, on top of the bucket.
+	tbfLatencyDivisor = 20
+)
+
+// setNetworkRate shapes the traffic of the endpoint of the container with tbf
+// qdiscs. The traffic sent by the container is shaped on its side of the veth
+// pair, in the network namespace of the sandbox, and the traffic it receives
+// on the host side.
+func setNetworkRate(container *container.Container, sb libnetwork.Sandbox, ep libnetwork.Endpoint) error {
+	resources := container.HostConfig.Resources
+	if resources.NetworkIngressRate == 0 && resources.NetworkEgressRate == 0 {
+		return nil
+	}
+	epInfo := ep.Info()
+	if epInfo == nil || epInfo.Iface() == nil || epInfo.Iface().MacAddress() == nil {
+		// The endpoint has no interface in the sandbox, like in the host
+		// network.
+		return nil
+	}
+	mac := epInfo.Iface().MacAddress()
+
+	var index, peerIndex int
+	err := invokeInNetNS(sb.Key(), func() error {
+		link, err := linkByMacAddress(mac)
+		if err != nil {
+			return err
+		}
+		index, peerIndex = link.Attrs().Index, link.Attrs().ParentIndex
+		if resources.NetworkEgressRate == 0 {
+			return nil
+		}
+		return replaceTbf(link, resources.NetworkEgressRate)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set the egress rate on network %s: %v", ep.Network(), err)
+	}
+
+	if resources.NetworkIngressRate == 0 {
+		return nil
+	}
+	// The peer of the interface is on the host for the veth pairs of the
+	// bridge driver, make sure it is not an unrelated interface with the
+	// same index for the other drivers.
+	peer, err := netlink.LinkByIndex(peerIndex)
+	if err != nil || peer.Type() != "veth" || peer.Attrs().ParentIndex != index {
+		return fmt.Errorf("failed to set the ingress rate on network %s: the interface of the container is not connected to the host", ep.Network())
+	}
+	if err := replaceTbf(peer, resources.NetworkIngressRate); err != nil {
+		return fmt.Errorf("failed to set the ingress rate on network %s: %v", ep.Network(), err)
+	}
+	return nil
+}
+
+// updateNetworkRate shapes the traffic of all the endpoints of the running
+// container with its current rates.
+func (daemon *Daemon) updateNetworkRate(container *container.Container) error {
+	sb := daemon.getNetworkSandbox(container)
+	if sb == nil {
+		return nil
+	}
+	for _, ep := range sb.Endpoints() {
+		if err := setNetworkRate(container, sb, ep); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replaceTbf replaces the root qdisc of the link with a tbf qdisc shaping its
+// traffic to the rate, in bytes per second.
+func replaceTbf(link netlink.Link, rate uint64) error {
+	burst := rate / tbfBurstDivisor
+	if burst < tbfMinBurst {
+		burst = tbfMinBurst
+	}
+	qdisc := &netlink.Tbf{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: link.Attrs().Index,
+			Handle:    netlink.MakeHandle(1, 0),
+			Parent:    netlink.HANDLE_ROOT,
+		},
+		Rate:   rate,
+		Limit:  uint32(rate/tbfLatencyDivisor + burst),
+		Buffer: uint32(netlink.Xmittime(rate, uint32(burst))),
+	}
+	return netlink.QdiscReplace(qdisc)
+}
+
+// linkByMacAddress returns the link of the current network namespace with the
+// hardware address.
+func linkByMacAddress(mac net.HardwareAddr) (netlink.Link, error) {
+	links, err := netlink.LinkList()
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range links {
+		if link.Attrs().HardwareAddr.String() == mac.String() {
+			return link, nil
+		}
+	}
+	return nil, fmt.Errorf("no interface with the address %s", mac)
+}
+
+// invokeInNetNS calls f in the network namespace at the path, and switches
+// back to the namespace of the daemon.
+func invokeInNetNS(path string, f func() error) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	origns, err := netns.Get()
+	if err != nil {
+		return err
+	}
+	defer origns.Close()
+
+	ns, err := netns.GetFromPath(path)
+	if err != nil {
+		return fmt.Errorf("failed to open the network namespace %s: %v", path, err)
+	}
+	defer ns.Close()
+
+	if err := netns.Set(ns); err != nil {
+		return fmt.Errorf("failed to enter the network namespace %s: %v", path, err)
+	}
+	defer netns.Set(origns)
+
+	return f()
+}
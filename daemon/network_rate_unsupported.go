@@ -0,0 +1,16 @@
+// +build !linux
+
+package daemon
+
+import (
+	"github.com/docker/docker/container"
+	"github.com/docker/libnetwork"
+)
+
+func setNetworkRate(container *container.Container, sb libnetwork.Sandbox, ep libnetwork.Endpoint) error {
+	return nil
+}
+
+func (daemon *Daemon) updateNetworkRate(container *container.Container) error {
+	return nil
+}
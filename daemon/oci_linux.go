@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -104,6 +105,14 @@ func setDevices(s *specs.Spec, c *container.Container) error {
 			devs = append(devs, d...)
 			devPermissions = append(devPermissions, dPermissions...)
 		}
+
+		for _, rule := range c.HostConfig.DeviceCgroupRules {
+			dPermission, err := parseDeviceCgroupRule(rule)
+			if err != nil {
+				return err
+			}
+			devPermissions = append(devPermissions, dPermission)
+		}
 	}
 
 	s.Linux.Devices = append(s.Linux.Devices, devs...)
@@ -111,6 +120,50 @@ func setDevices(s *specs.Spec, c *container.Container) error {
 	return nil
 }
 
+// parseDeviceCgroupRule converts a rule of the devices cgroup, in the form
+// `type major:minor access`, into an allowed device of the spec. A major or
+// minor number of `*` matches all the devices.
+func parseDeviceCgroupRule(rule string) (specs.DeviceCgroup, error) {
+	fields := strings.Fields(rule)
+	if len(fields) != 3 {
+		return specs.DeviceCgroup{}, fmt.Errorf("invalid device cgroup rule format: '%s'", rule)
+	}
+	numbers := strings.SplitN(fields[1], ":", 2)
+	if len(numbers) != 2 {
+		return specs.DeviceCgroup{}, fmt.Errorf("invalid device cgroup rule format: '%s'", rule)
+	}
+
+	major, err := parseDeviceNumber(numbers[0])
+	if err != nil {
+		return specs.DeviceCgroup{}, fmt.Errorf("invalid device cgroup rule format: '%s'", rule)
+	}
+	minor, err := parseDeviceNumber(numbers[1])
+	if err != nil {
+		return specs.DeviceCgroup{}, fmt.Errorf("invalid device cgroup rule format: '%s'", rule)
+	}
+
+	return specs.DeviceCgroup{
+		Allow:  true,
+		Type:   &fields[0],
+		Major:  major,
+		Minor:  minor,
+		Access: &fields[2],
+	}, nil
+}
+
+// parseDeviceNumber parses the major or minor number of a device, `*` is
+// returned as nil to match all the devices.
+func parseDeviceNumber(number string) (*int64, error) {
+	if number == "*" {
+		return nil, nil
+	}
+	n, err := strconv.ParseInt(number, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
 func setRlimits(daemon *Daemon, s *specs.Spec, c *container.Container) error {
 	var rlimits []specs.Rlimit
 
@@ -243,7 +296,7 @@ func setNamespaces(daemon *Daemon, s *specs.Spec, c *container.Container) error
 	userNS := false
 	// user
 	if c.HostConfig.UsernsMode.IsPrivate() {
-		uidMap, gidMap := daemon.GetUIDGIDMaps()
+		uidMap, gidMap := daemon.getContainerIDMaps(c)
 		if uidMap != nil {
 			userNS = true
 			ns := specs.Namespace{Type: "user"}
@@ -542,7 +595,7 @@ func setMounts(daemon *Daemon, s *specs.Spec, c *container.Container, mounts []c
 
 	// TODO: until a kernel/mount solution exists for handling remount in a user namespace,
 	// we must clear the readonly flag for the cgroups mount (@mrunalp concurs)
-	if uidMap, _ := daemon.GetUIDGIDMaps(); uidMap != nil || c.HostConfig.Privileged {
+	if uidMap, _ := daemon.getContainerIDMaps(c); uidMap != nil || c.HostConfig.Privileged {
 		for i, m := range s.Mounts {
 			if m.Type == "cgroup" {
 				clearReadOnly(&s.Mounts[i])
@@ -562,7 +615,7 @@ func (daemon *Daemon) populateCommonSpec(s *specs.Spec, c *container.Container)
 		Path:     c.BaseFS,
 		Readonly: c.HostConfig.ReadonlyRootfs,
 	}
-	rootUID, rootGID := daemon.GetRemappedUIDGID()
+	rootUID, rootGID := daemon.getContainerRemappedUIDGID(c)
 	if err := c.SetupWorkingDirectory(rootUID, rootGID); err != nil {
 		return err
 	}
@@ -571,6 +624,29 @@ func (daemon *Daemon) populateCommonSpec(s *specs.Spec, c *container.Container)
 		cwd = "/"
 	}
 	s.Process.Args = append([]string{c.Path}, c.Args...)
+
+	// only add the custom init if it is specified and the container is running in its
+	// own private pid namespace.  It does not make sense to add if it is running in the
+	// host namespace or another container's pid namespace where we already have an init
+	if c.HostConfig.PidMode.IsPrivate() {
+		if (c.HostConfig.Init != nil && *c.HostConfig.Init) ||
+			(c.HostConfig.Init == nil && daemon.configStore.Init) {
+			s.Process.Args = append([]string{"/dev/init", "--", c.Path}, c.Args...)
+			path := daemon.configStore.InitPath
+			if path == "" {
+				path, err = exec.LookPath(DefaultInitBinary)
+				if err != nil {
+					return err
+				}
+			}
+			s.Mounts = append(s.Mounts, specs.Mount{
+				Destination: "/dev/init",
+				Type:        "bind",
+				Source:      path,
+				Options:     []string{"bind", "ro"},
+			})
+		}
+	}
 	s.Process.Cwd = cwd
 	s.Process.Env = c.CreateDaemonEnvironment(linkedEnv)
 	s.Process.Terminal = c.Config.Tty
@@ -0,0 +1,27 @@
+package daemon
+
+import (
+	"testing"
+)
+
+func TestParseDeviceCgroupRule(t *testing.T) {
+	dPermission, err := parseDeviceCgroupRule("c 188:* rmw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !dPermission.Allow || *dPermission.Type != "c" || *dPermission.Access != "rmw" {
+		t.Fatalf("unexpected device permission %+v", dPermission)
+	}
+	if dPermission.Major == nil || *dPermission.Major != 188 {
+		t.Fatalf("expected major 188, got %v", dPermission.Major)
+	}
+	if dPermission.Minor != nil {
+		t.Fatalf("expected all the minors to match, got %v", *dPermission.Minor)
+	}
+
+	for _, rule := range []string{"c 188 rmw", "c a:1 rmw", "c 1:3"} {
+		if _, err := parseDeviceCgroupRule(rule); err == nil {
+			t.Fatalf("expected an error for %q", rule)
+		}
+	}
+}
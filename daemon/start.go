@@ -12,6 +12,7 @@ import (
 	"github.com/docker/docker/errors"
 	"github.com/docker/docker/libcontainerd"
 	"github.com/docker/docker/runconfig"
+	"github.com/docker/engine-api/types"
 	containertypes "github.com/docker/engine-api/types/container"
 )
 
@@ -107,6 +108,15 @@ func (daemon *Daemon) containerStart(container *container.Container) (err error)
 			}
 			container.ToDisk()
 			daemon.Cleanup(container)
+			// if the container was created with AutoRemove, remove it now
+			// that it is cleaned up, the container lock is needed to do so.
+			if container.HostConfig.AutoRemove {
+				container.Unlock()
+				if err := daemon.ContainerRm(container.ID, &types.ContainerRmConfig{ForceRemove: true, RemoveVolume: true}); err != nil {
+					logrus.Errorf("Failed to remove container %s after it failed to start: %v", container.ID, err)
+				}
+				container.Lock()
+			}
 		}
 	}()
 
@@ -127,7 +137,13 @@ func (daemon *Daemon) containerStart(container *container.Container) (err error)
 		return err
 	}
 
-	if err := daemon.containerd.Create(container.ID, *spec, libcontainerd.WithRestartManager(container.RestartManager(true))); err != nil {
+	createOptions, err := daemon.getLibcontainerdCreateOptions(container)
+	if err != nil {
+		return err
+	}
+	createOptions = append(createOptions, libcontainerd.WithRestartManager(container.RestartManager(true)))
+
+	if err := daemon.containerd.Create(container.ID, *spec, createOptions...); err != nil {
 		// if we receive an internal error from the initial start of a container then lets
 		// return it instead of entering the restart loop
 		// set to 127 for container cmd not found/does not exist)
@@ -166,9 +182,7 @@ func (daemon *Daemon) Cleanup(container *container.Container) {
 		}
 	}
 
-	for _, eConfig := range container.ExecCommands.Commands() {
-		daemon.unregisterExecCommand(container, eConfig)
-	}
+	daemon.exitExecCommands(container)
 
 	if container.BaseFS != "" {
 		if err := container.UnmountVolumes(false, daemon.LogVolumeEvent); err != nil {
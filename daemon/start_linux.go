@@ -0,0 +1,25 @@
+package daemon
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/libcontainerd"
+)
+
+// getLibcontainerdCreateOptions returns the platform specific options used
+// by containerd to run the container.
+func (daemon *Daemon) getLibcontainerdCreateOptions(container *container.Container) ([]libcontainerd.CreateOption, error) {
+	// Containers created before runtimes could be selected use the
+	// default one.
+	name := container.HostConfig.Runtime
+	if name == "" {
+		name = daemon.configStore.GetDefaultRuntimeName()
+	}
+	rt := daemon.configStore.GetRuntime(name)
+	if rt == nil {
+		return nil, fmt.Errorf("no such runtime '%s'", name)
+	}
+
+	return []libcontainerd.CreateOption{libcontainerd.WithRuntime(rt.Path, rt.Args)}, nil
+}
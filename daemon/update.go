@@ -62,6 +62,10 @@ func (daemon *Daemon) update(name string, hostConfig *container.HostConfig) erro
 		return errCannotUpdate(container.ID, fmt.Errorf("Container is marked for removal and cannot be \"update\"."))
 	}
 
+	if container.HostConfig.AutoRemove && hostConfig.RestartPolicy.Name != "" && !hostConfig.RestartPolicy.IsNone() {
+		return errCannotUpdate(container.ID, fmt.Errorf("Restart policy cannot be updated because AutoRemove is enabled for the container"))
+	}
+
 	if container.IsRunning() && hostConfig.KernelMemory != 0 {
 		return errCannotUpdate(container.ID, fmt.Errorf("Can not update kernel memory to a running container, please stop it first."))
 	}
@@ -88,6 +92,12 @@ func (daemon *Daemon) update(name string, hostConfig *container.HostConfig) erro
 			restoreConfig = true
 			return errCannotUpdate(container.ID, err)
 		}
+		if hostConfig.NetworkIngressRate != 0 || hostConfig.NetworkEgressRate != 0 {
+			if err := daemon.updateNetworkRate(container); err != nil {
+				restoreConfig = true
+				return errCannotUpdate(container.ID, err)
+			}
+		}
 	}
 
 	daemon.LogContainerEvent(container, "update")
@@ -13,6 +13,11 @@ func toContainerdResources(resources container.Resources) libcontainerd.Resource
 	r.CpuShares = uint32(resources.CPUShares)
 	r.CpuPeriod = uint32(resources.CPUPeriod)
 	r.CpuQuota = uint32(resources.CPUQuota)
+	if resources.NanoCPUs > 0 {
+		period, quota := nanoCPUsToCFS(resources.NanoCPUs)
+		r.CpuPeriod = uint32(period)
+		r.CpuQuota = uint32(quota)
+	}
 	r.CpusetCpus = resources.CpusetCpus
 	r.CpusetMems = resources.CpusetMems
 	r.MemoryLimit = uint32(resources.Memory)
@@ -0,0 +1,121 @@
+package daemon
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/docker/docker/pkg/idtools"
+)
+
+// usernsGroupLabel is the label of the containers running with
+// --userns=isolated which share a user namespace mapping with the other
+// containers carrying the same value.
+const usernsGroupLabel = "com.docker.userns.group"
+
+// usernsBlockSize is the number of IDs in the user namespace mapping of a
+// container, the same as the default size of a subordinate ID range.
+const usernsBlockSize = 65536
+
+// idRangeAllocator hands out user namespace mappings to the containers
+// running with --userns=isolated. Each mapping covers a distinct block of
+// the subordinate IDs of the daemon, so that these containers cannot access
+// each other's files, unless they share the same group.
+type idRangeAllocator struct {
+	sync.Mutex
+	uidBlocks [][]idtools.IDMap
+	gidBlocks [][]idtools.IDMap
+	// users holds the IDs of the containers using each block.
+	users map[int]map[string]struct{}
+	// groups holds the block used by each group of containers.
+	groups map[string]int
+}
+
+func newIDRangeAllocator(uidBlocks, gidBlocks [][]idtools.IDMap) *idRangeAllocator {
+	if len(gidBlocks) < len(uidBlocks) {
+		uidBlocks = uidBlocks[:len(gidBlocks)]
+	} else {
+		gidBlocks = gidBlocks[:len(uidBlocks)]
+	}
+	return &idRangeAllocator{
+		uidBlocks: uidBlocks,
+		gidBlocks: gidBlocks,
+		users:     make(map[int]map[string]struct{}),
+		groups:    make(map[string]int),
+	}
+}
+
+// allocate returns the user namespace mapping of a new container. If group
+// is not empty and other containers of the group exist, their mapping is
+// returned. Otherwise a free block is used.
+func (a *idRangeAllocator) allocate(containerID, group string) ([]idtools.IDMap, []idtools.IDMap, error) {
+	a.Lock()
+	defer a.Unlock()
+
+	if group != "" {
+		if idx, ok := a.groups[group]; ok {
+			a.use(idx, containerID, group)
+			return a.uidBlocks[idx], a.gidBlocks[idx], nil
+		}
+	}
+	for idx := range a.uidBlocks {
+		if len(a.users[idx]) == 0 {
+			a.use(idx, containerID, group)
+			return a.uidBlocks[idx], a.gidBlocks[idx], nil
+		}
+	}
+	return nil, nil, fmt.Errorf("no subordinate ID range left for an isolated user namespace")
+}
+
+// reserve marks the block mapped by uidMaps as used by an existing
+// container. It returns false if the mapping does not belong to any block,
+// for example if the subordinate ID ranges of the daemon have changed.
+func (a *idRangeAllocator) reserve(containerID, group string, uidMaps []idtools.IDMap) bool {
+	if len(uidMaps) == 0 {
+		return false
+	}
+
+	a.Lock()
+	defer a.Unlock()
+
+	for idx, block := range a.uidBlocks {
+		if block[0].HostID == uidMaps[0].HostID {
+			a.use(idx, containerID, group)
+			return true
+		}
+	}
+	return false
+}
+
+// release frees the block used by a container once no other container of
+// its group uses it.
+func (a *idRangeAllocator) release(containerID string) {
+	a.Lock()
+	defer a.Unlock()
+
+	for idx, users := range a.users {
+		if _, ok := users[containerID]; !ok {
+			continue
+		}
+		delete(users, containerID)
+		if len(users) > 0 {
+			return
+		}
+		delete(a.users, idx)
+		for group, i := range a.groups {
+			if i == idx {
+				delete(a.groups, group)
+			}
+		}
+		return
+	}
+}
+
+func (a *idRangeAllocator) use(idx int, containerID, group string) {
+	if a.users[idx] == nil {
+		a.users[idx] = make(map[string]struct{})
+	}
+	a.users[idx][containerID] = struct{}{}
+	if group != "" {
+		a.groups[group] = idx
+	}
+}
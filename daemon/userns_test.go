@@ -0,0 +1,61 @@
+package daemon
+
+import (
+	"testing"
+
+	"github.com/docker/docker/pkg/idtools"
+)
+
+func TestIDRangeAllocator(t *testing.T) {
+	var blocks [][]idtools.IDMap
+	for i := 0; i < 2; i++ {
+		blocks = append(blocks, []idtools.IDMap{{ContainerID: 0, HostID: 200000 + i*usernsBlockSize, Size: usernsBlockSize}})
+	}
+	a := newIDRangeAllocator(blocks, blocks)
+
+	uidMaps, _, err := a.allocate("c1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uidMaps[0].HostID != 200000 {
+		t.Fatalf("wanted the first block, got %v", uidMaps)
+	}
+	uidMaps, _, err = a.allocate("c2", "tenant")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uidMaps[0].HostID != 200000+usernsBlockSize {
+		t.Fatalf("wanted the second block, got %v", uidMaps)
+	}
+	uidMaps, _, err = a.allocate("c3", "tenant")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uidMaps[0].HostID != 200000+usernsBlockSize {
+		t.Fatalf("wanted the block of the group, got %v", uidMaps)
+	}
+	if _, _, err := a.allocate("c4", ""); err == nil {
+		t.Fatal("expected an error when no block is left")
+	}
+
+	a.release("c2")
+	if _, _, err := a.allocate("c4", ""); err == nil {
+		t.Fatal("expected the block to stay in use by the group")
+	}
+	a.release("c3")
+	uidMaps, _, err = a.allocate("c4", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uidMaps[0].HostID != 200000+usernsBlockSize {
+		t.Fatalf("wanted the released block, got %v", uidMaps)
+	}
+
+	a.release("c1")
+	if !a.reserve("c5", "", blocks[0]) {
+		t.Fatal("expected the mapping to be reserved")
+	}
+	if a.reserve("c6", "", []idtools.IDMap{{ContainerID: 0, HostID: 1000, Size: usernsBlockSize}}) {
+		t.Fatal("expected a mapping outside of the blocks not to be reserved")
+	}
+}
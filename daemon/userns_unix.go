@@ -0,0 +1,136 @@
+// +build linux freebsd
+
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/idtools"
+)
+
+// setupUsernsIsolation splits the subordinate ID ranges of the remapped root
+// when containers are allowed to run with their own user namespace mapping.
+// The first block is used by the daemon, for the layer store and the
+// containers sharing its mapping, and the others are handed out to isolated
+// containers. It returns the mappings of the daemon.
+func setupUsernsIsolation(config *Config, uidMaps, gidMaps []idtools.IDMap) ([]idtools.IDMap, []idtools.IDMap, *idRangeAllocator, error) {
+	if !config.UsernsIsolation {
+		return uidMaps, gidMaps, nil, nil
+	}
+	if uidMaps == nil {
+		return nil, nil, nil, fmt.Errorf("--userns-isolation requires --userns-remap")
+	}
+
+	uidBlocks := idtools.SplitIDMap(uidMaps, usernsBlockSize)
+	gidBlocks := idtools.SplitIDMap(gidMaps, usernsBlockSize)
+	if len(uidBlocks) < 2 || len(gidBlocks) < 2 {
+		return nil, nil, nil, fmt.Errorf("--userns-isolation requires the subordinate ID ranges of %s to hold at least %d IDs", config.RemappedRoot, 2*usernsBlockSize)
+	}
+	logrus.Infof("User namespaces: %d ID ranges available for isolated containers", len(uidBlocks)-1)
+	return uidBlocks[0], gidBlocks[0], newIDRangeAllocator(uidBlocks[1:], gidBlocks[1:]), nil
+}
+
+// getContainerIDMaps returns the user namespace mappings of the container,
+// which are the daemon's unless it runs with --userns=isolated.
+func (daemon *Daemon) getContainerIDMaps(c *container.Container) ([]idtools.IDMap, []idtools.IDMap) {
+	if c.UIDMaps != nil {
+		return c.UIDMaps, c.GIDMaps
+	}
+	return daemon.GetUIDGIDMaps()
+}
+
+// getContainerRemappedUIDGID returns the host uid and gid of the root user
+// of the container.
+func (daemon *Daemon) getContainerRemappedUIDGID(c *container.Container) (int, int) {
+	uidMaps, gidMaps := daemon.getContainerIDMaps(c)
+	uid, gid, _ := idtools.GetRootUIDGID(uidMaps, gidMaps)
+	return uid, gid
+}
+
+// setupContainerIDMaps allocates the user namespace mapping of a container
+// running with --userns=isolated, and shifts the ownership of its root
+// filesystem from the daemon's mapping to it. The container must be mounted.
+//
+// Changing the ownership copies the files of the image into the read-write
+// layer of the container on drivers which share the image layers, trading
+// disk space for the isolation of the container.
+func (daemon *Daemon) setupContainerIDMaps(c *container.Container) error {
+	if !c.HostConfig.UsernsMode.IsIsolated() {
+		return nil
+	}
+
+	uidMaps, gidMaps, err := daemon.idRanges.allocate(c.ID, c.Config.Labels[usernsGroupLabel])
+	if err != nil {
+		return err
+	}
+	c.UIDMaps, c.GIDMaps = uidMaps, gidMaps
+
+	daemonUIDMaps, daemonGIDMaps := daemon.GetUIDGIDMaps()
+	return shiftOwnership(c.BaseFS, daemonUIDMaps, daemonGIDMaps, uidMaps, gidMaps)
+}
+
+// restoreContainerIDMaps marks the user namespace mapping of an existing
+// container as used.
+func (daemon *Daemon) restoreContainerIDMaps(c *container.Container) {
+	if c.UIDMaps == nil || daemon.idRanges == nil {
+		return
+	}
+	if !daemon.idRanges.reserve(c.ID, c.Config.Labels[usernsGroupLabel], c.UIDMaps) {
+		logrus.Warnf("User namespace mapping of container %s is outside the ranges available for isolated containers", c.ID)
+	}
+}
+
+// releaseContainerIDMaps frees the user namespace mapping of a removed
+// container.
+func (daemon *Daemon) releaseContainerIDMaps(c *container.Container) {
+	if c.UIDMaps == nil || daemon.idRanges == nil {
+		return
+	}
+	daemon.idRanges.release(c.ID)
+}
+
+// shiftOwnership changes the owner of every file under root from an ID in
+// the source mapping to the same container ID in the target mapping. Files
+// owned by IDs outside of the source mapping are left alone.
+func shiftOwnership(root string, fromUIDMaps, fromGIDMaps, toUIDMaps, toGIDMaps []idtools.IDMap) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		stat, ok := info.Sys().(*syscall.Stat_t)
+		if !ok {
+			return nil
+		}
+
+		uid, err := shiftID(int(stat.Uid), fromUIDMaps, toUIDMaps)
+		if err != nil {
+			return nil
+		}
+		gid, err := shiftID(int(stat.Gid), fromGIDMaps, toGIDMaps)
+		if err != nil {
+			return nil
+		}
+		if err := os.Lchown(path, uid, gid); err != nil {
+			return err
+		}
+
+		// chown clears the setuid and setgid bits, restore them.
+		if info.Mode()&os.ModeSymlink == 0 && info.Mode()&(os.ModeSetuid|os.ModeSetgid) != 0 {
+			return os.Chmod(path, info.Mode())
+		}
+		return nil
+	})
+}
+
+func shiftID(hostID int, from, to []idtools.IDMap) (int, error) {
+	containerID, err := idtools.ToContainer(hostID, from)
+	if err != nil {
+		return -1, err
+	}
+	return idtools.ToHost(containerID, to)
+}
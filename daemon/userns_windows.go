@@ -0,0 +1,24 @@
+package daemon
+
+import (
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/idtools"
+)
+
+func setupUsernsIsolation(config *Config, uidMaps, gidMaps []idtools.IDMap) ([]idtools.IDMap, []idtools.IDMap, *idRangeAllocator, error) {
+	return uidMaps, gidMaps, nil, nil
+}
+
+func (daemon *Daemon) getContainerIDMaps(c *container.Container) ([]idtools.IDMap, []idtools.IDMap) {
+	return daemon.GetUIDGIDMaps()
+}
+
+func (daemon *Daemon) getContainerRemappedUIDGID(c *container.Container) (int, int) {
+	return daemon.GetRemappedUIDGID()
+}
+
+func (daemon *Daemon) restoreContainerIDMaps(c *container.Container) {
+}
+
+func (daemon *Daemon) releaseContainerIDMaps(c *container.Container) {
+}
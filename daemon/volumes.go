@@ -8,9 +8,11 @@ import (
 	"strings"
 
 	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/stringid"
 	"github.com/docker/docker/volume"
 	"github.com/docker/engine-api/types"
 	containertypes "github.com/docker/engine-api/types/container"
+	mounttypes "github.com/docker/engine-api/types/mount"
 	"github.com/opencontainers/runc/libcontainer/label"
 )
 
@@ -64,7 +66,8 @@ func (m mounts) parts(i int) int {
 // 1. Select the previously configured mount points for the containers, if any.
 // 2. Select the volumes mounted from another containers. Overrides previously configured mount point destination.
 // 3. Select the bind mounts set by the client. Overrides previously configured mount point destinations.
-// 4. Cleanup old volumes that are about to be reassigned.
+// 4. Select the typed mounts set by the client. Overrides previously configured mount point destinations.
+// 5. Cleanup old volumes that are about to be reassigned.
 func (daemon *Daemon) registerMountPoints(container *container.Container, hostConfig *containertypes.HostConfig) error {
 	binds := map[string]bool{}
 	mountPoints := map[string]*volume.MountPoint{}
@@ -146,9 +149,70 @@ func (daemon *Daemon) registerMountPoints(container *container.Container, hostCo
 		mountPoints[bind.Destination] = bind
 	}
 
+	// 4. Read typed mounts
+	for _, m := range hostConfig.Mounts {
+		mp, err := volume.ParseMount(m, hostConfig.VolumeDriver)
+		if err != nil {
+			return err
+		}
+
+		destination := filepath.Clean(m.Target)
+		if binds[destination] {
+			return fmt.Errorf("Duplicate mount point '%s'", destination)
+		}
+		binds[destination] = true
+
+		// tmpfs mounts are set up along with the container's other tmpfs
+		// mounts, they don't have a mount point.
+		if mp == nil {
+			if _, exists := hostConfig.Tmpfs[destination]; exists {
+				return fmt.Errorf("Duplicate mount point '%s'", destination)
+			}
+			continue
+		}
+
+		if m.Type == mounttypes.TypeVolume {
+			var (
+				driverOpts map[string]string
+				labels     map[string]string
+			)
+			if m.VolumeOptions != nil {
+				labels = m.VolumeOptions.Labels
+				if m.VolumeOptions.DriverConfig != nil {
+					driverOpts = m.VolumeOptions.DriverConfig.Options
+				}
+			}
+
+			// Volumes without a name are anonymous, they are removed along
+			// with the container like the ones defined by the image.
+			name := mp.Name
+			if name == "" {
+				name = stringid.GenerateNonCryptoID()
+			}
+			v, err := daemon.volumes.CreateWithRef(name, mp.Driver, container.ID, driverOpts, labels)
+			if err != nil {
+				return err
+			}
+			mp.Volume = v
+			mp.Name = v.Name()
+			mp.Driver = v.DriverName()
+			mp.Named = len(m.Source) > 0
+			if mp.Driver == volume.DefaultDriverName {
+				mp = setBindModeIfNull(mp)
+			}
+		}
+
+		if label.RelabelNeeded(mp.Mode) {
+			if err := label.Relabel(mp.Source, container.MountLabel, label.IsShared(mp.Mode)); err != nil {
+				return err
+			}
+		}
+		mountPoints[mp.Destination] = mp
+	}
+
 	container.Lock()
 
-	// 4. Cleanup old volumes that are about to be reassigned.
+	// 5. Cleanup old volumes that are about to be reassigned.
 	for _, m := range mountPoints {
 		if m.BackwardsCompatible() {
 			if mp, exists := container.MountPoints[m.Destination]; exists && mp.Volume != nil {
@@ -14,6 +14,7 @@ var clientFlags = &cli.ClientFlags{FlagSet: new(flag.FlagSet), Common: commonFla
 func init() {
 	client := clientFlags.FlagSet
 	client.StringVar(&clientFlags.ConfigDir, []string{"-config"}, cliconfig.ConfigDir(), "Location of client config files")
+	client.StringVar(&clientFlags.Context, []string{"-context"}, "", "Name of the endpoint of the client config to connect to")
 
 	clientFlags.PostParse = func() {
 		clientFlags.Common.PostParse()
@@ -325,6 +325,12 @@ copy_containerd() {
 				fi
 			done
 		fi
+		if [ -x /usr/local/bin/docker-init ]; then
+			cp /usr/local/bin/docker-init "$dir/"
+			if [ "$2" == "hash" ]; then
+				hash_files "$dir/docker-init"
+			fi
+		fi
 	fi
 }
 
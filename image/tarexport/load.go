@@ -8,8 +8,10 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/docker/distribution/digest"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/image/v1"
 	"github.com/docker/docker/layer"
@@ -49,6 +51,13 @@ func (l *tarexporter) Load(inTar io.ReadCloser, outStream io.Writer, quiet bool)
 	manifestFile, err := os.Open(manifestPath)
 	if err != nil {
 		if os.IsNotExist(err) {
+			ociLayoutPath, err := safePath(tmpDir, ociLayoutFileName)
+			if err != nil {
+				return err
+			}
+			if _, err := os.Stat(ociLayoutPath); err == nil {
+				return l.ociLoad(tmpDir, outStream, progressOutput)
+			}
 			return l.legacyLoad(tmpDir, outStream, progressOutput)
 		}
 		return manifestFile.Close()
@@ -334,6 +343,193 @@ func (l *tarexporter) legacyLoadImage(oldID, sourceDir string, loadedMap map[str
 	return nil
 }
 
+// ociLoad loads the images of an OCI image layout. The images are read from
+// the manifests listed in index.json, and in the image indexes it refers to,
+// and are tagged with the reference names of their descriptors.
+func (l *tarexporter) ociLoad(tmpDir string, outStream io.Writer, progressOutput progress.Output) error {
+	var layout ociLayout
+	if err := readJSONFile(tmpDir, ociLayoutFileName, &layout); err != nil {
+		return err
+	}
+	if layout.ImageLayoutVersion != ociLayoutVersion {
+		return fmt.Errorf("unsupported OCI image layout version %q", layout.ImageLayoutVersion)
+	}
+
+	var index ociIndex
+	if err := readJSONFile(tmpDir, ociIndexFileName, &index); err != nil {
+		return err
+	}
+	return l.ociLoadIndex(tmpDir, index, make(map[digest.Digest]image.ID), outStream, progressOutput)
+}
+
+func (l *tarexporter) ociLoadIndex(tmpDir string, index ociIndex, loaded map[digest.Digest]image.ID, outStream io.Writer, progressOutput progress.Output) error {
+	if index.SchemaVersion != ociSchemaVersion {
+		return fmt.Errorf("unsupported OCI image index schema version %d", index.SchemaVersion)
+	}
+
+	for _, desc := range index.Manifests {
+		switch desc.MediaType {
+		case ociIndexMediaType:
+			var nested ociIndex
+			if err := readJSONBlob(tmpDir, desc, &nested); err != nil {
+				return err
+			}
+			if err := l.ociLoadIndex(tmpDir, nested, loaded, outStream, progressOutput); err != nil {
+				return err
+			}
+		case ociManifestType:
+			imgID, ok := loaded[desc.Digest]
+			if !ok {
+				var err error
+				if imgID, err = l.ociLoadManifest(tmpDir, desc, progressOutput); err != nil {
+					return err
+				}
+				loaded[desc.Digest] = imgID
+			}
+			ref, err := ociReference(desc.Annotations)
+			if err != nil {
+				return err
+			}
+			if ref != nil {
+				l.setLoadedTag(ref, imgID, outStream)
+			}
+		default:
+			logrus.Debugf("Skipping OCI descriptor %s with media type %q", desc.Digest, desc.MediaType)
+		}
+	}
+	return nil
+}
+
+func (l *tarexporter) ociLoadManifest(tmpDir string, desc ociDescriptor, progressOutput progress.Output) (image.ID, error) {
+	var manifest ociManifest
+	if err := readJSONBlob(tmpDir, desc, &manifest); err != nil {
+		return "", err
+	}
+	if manifest.SchemaVersion != ociSchemaVersion {
+		return "", fmt.Errorf("unsupported OCI image manifest schema version %d", manifest.SchemaVersion)
+	}
+	if mt := manifest.Config.MediaType; mt != ociConfigType && mt != dockerConfigType {
+		return "", fmt.Errorf("unsupported image config media type %q", mt)
+	}
+
+	config, err := readBlob(tmpDir, manifest.Config)
+	if err != nil {
+		return "", err
+	}
+	img, err := image.NewFromJSON(config)
+	if err != nil {
+		return "", err
+	}
+	var rootFS image.RootFS
+	rootFS = *img.RootFS
+	rootFS.DiffIDs = nil
+
+	if expected, actual := len(manifest.Layers), len(img.RootFS.DiffIDs); expected != actual {
+		return "", fmt.Errorf("invalid manifest, layers length mismatch: expected %d, got %d", expected, actual)
+	}
+
+	for i, diffID := range img.RootFS.DiffIDs {
+		switch mt := manifest.Layers[i].MediaType; mt {
+		case ociLayerType, ociLayerGzipType, dockerLayerGzipType:
+		default:
+			return "", fmt.Errorf("unsupported layer media type %q", mt)
+		}
+		r := rootFS
+		r.Append(diffID)
+		newLayer, err := l.ls.Get(r.ChainID())
+		if err != nil {
+			layerPath, err := blobPath(tmpDir, manifest.Layers[i].Digest)
+			if err != nil {
+				return "", err
+			}
+			newLayer, err = l.loadLayer(layerPath, rootFS, diffID.String(), progressOutput)
+			if err != nil {
+				return "", err
+			}
+		}
+		defer layer.ReleaseAndLog(l.ls, newLayer)
+		if expected, actual := diffID, newLayer.DiffID(); expected != actual {
+			return "", fmt.Errorf("invalid diffID for layer %d: expected %q, got %q", i, expected, actual)
+		}
+		rootFS.Append(diffID)
+	}
+
+	return l.is.Create(config)
+}
+
+// ociReference returns the reference an image is tagged with in the
+// annotations of its descriptor, or nil if it has none. The full reference
+// is read from the image name annotation, or else from the reference name
+// if it is a full reference and not only a tag.
+func ociReference(annotations map[string]string) (reference.NamedTagged, error) {
+	name, ok := annotations[ociImageNameKey]
+	if !ok {
+		name = annotations[ociRefNameKey]
+		if name == "" || !strings.ContainsAny(name, "/:") {
+			return nil, nil
+		}
+	}
+	named, err := reference.ParseNamed(name)
+	if err != nil {
+		return nil, err
+	}
+	ref, ok := reference.WithDefaultTag(named).(reference.NamedTagged)
+	if !ok {
+		return nil, fmt.Errorf("invalid tag %q", name)
+	}
+	return ref, nil
+}
+
+// blobPath returns the path of the blob with the given digest in an OCI
+// image layout.
+func blobPath(base string, dgst digest.Digest) (string, error) {
+	if err := dgst.Validate(); err != nil {
+		return "", err
+	}
+	return safePath(base, filepath.Join(ociBlobsDirName, string(dgst.Algorithm()), dgst.Hex()))
+}
+
+// readBlob reads the blob of a descriptor and verifies its digest.
+func readBlob(base string, desc ociDescriptor) ([]byte, error) {
+	p, err := blobPath(base, desc.Digest)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := digest.NewDigestVerifier(desc.Digest)
+	if err != nil {
+		return nil, err
+	}
+	verifier.Write(data)
+	if !verifier.Verified() {
+		return nil, fmt.Errorf("invalid content for blob %s", desc.Digest)
+	}
+	return data, nil
+}
+
+func readJSONBlob(base string, desc ociDescriptor, v interface{}) error {
+	data, err := readBlob(base, desc)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func readJSONFile(base, name string, v interface{}) error {
+	p, err := safePath(base, name)
+	if err != nil {
+		return err
+	}
+	data, err := ioutil.ReadFile(p)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
 func safePath(base, path string) (string, error) {
 	return symlink.FollowSymlinkInScope(filepath.Join(base, path), base)
 }
@@ -25,18 +25,27 @@ type imageDescriptor struct {
 
 type saveSession struct {
 	*tarexporter
-	outDir      string
-	images      map[image.ID]*imageDescriptor
-	savedLayers map[string]struct{}
+	outDir         string
+	images         map[image.ID]*imageDescriptor
+	savedLayers    map[string]struct{}
+	savedOCILayers map[layer.ChainID]ociDescriptor
 }
 
-func (l *tarexporter) Save(names []string, outStream io.Writer) error {
+func (l *tarexporter) Save(names []string, outStream io.Writer, format string) error {
+	if format != "" && format != formatDocker && format != formatOCI {
+		return fmt.Errorf("invalid format %q, must be %q or %q", format, formatDocker, formatOCI)
+	}
+
 	images, err := l.parseNames(names)
 	if err != nil {
 		return err
 	}
 
-	return (&saveSession{tarexporter: l, images: images}).save(outStream)
+	s := &saveSession{tarexporter: l, images: images}
+	if format == formatOCI {
+		return s.saveOCI(outStream)
+	}
+	return s.save(outStream)
 }
 
 func (l *tarexporter) parseNames(names []string) (map[image.ID]*imageDescriptor, error) {
@@ -317,3 +326,189 @@ func (s *saveSession) saveLayer(id layer.ChainID, legacyImg image.V1Image, creat
 	s.savedLayers[legacyImg.ID] = struct{}{}
 	return nil
 }
+
+// saveOCI writes the images as an OCI image layout. Every tag of an image
+// gets its own entry in index.json, and the layers are written uncompressed
+// so that their digests are the diff IDs of the layers.
+func (s *saveSession) saveOCI(outStream io.Writer) error {
+	s.savedOCILayers = make(map[layer.ChainID]ociDescriptor)
+
+	tempDir, err := ioutil.TempDir("", "docker-export-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	s.outDir = tempDir
+	if err := os.MkdirAll(filepath.Join(tempDir, ociBlobsDirName, string(digest.Canonical)), 0755); err != nil {
+		return err
+	}
+
+	index := ociIndex{
+		SchemaVersion: ociSchemaVersion,
+		Manifests:     []ociDescriptor{},
+	}
+	for id, imageDescr := range s.images {
+		desc, err := s.saveOCIImage(id)
+		if err != nil {
+			return err
+		}
+		if len(imageDescr.refs) == 0 {
+			index.Manifests = append(index.Manifests, desc)
+		}
+		for _, ref := range imageDescr.refs {
+			d := desc
+			d.Annotations = map[string]string{
+				ociImageNameKey: ref.String(),
+				ociRefNameKey:   ref.Tag(),
+			}
+			index.Manifests = append(index.Manifests, d)
+		}
+	}
+
+	if err := writeJSONFile(filepath.Join(tempDir, ociLayoutFileName), ociLayout{ImageLayoutVersion: ociLayoutVersion}); err != nil {
+		return err
+	}
+	if err := writeJSONFile(filepath.Join(tempDir, ociIndexFileName), index); err != nil {
+		return err
+	}
+
+	fs, err := archive.Tar(tempDir, archive.Uncompressed)
+	if err != nil {
+		return err
+	}
+	defer fs.Close()
+
+	if _, err := io.Copy(outStream, fs); err != nil {
+		return err
+	}
+	return nil
+}
+
+// saveOCIImage writes the layers, config and manifest of an image as blobs
+// and returns the descriptor of the manifest.
+func (s *saveSession) saveOCIImage(id image.ID) (ociDescriptor, error) {
+	img, err := s.is.Get(id)
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+
+	if len(img.RootFS.DiffIDs) == 0 {
+		return ociDescriptor{}, fmt.Errorf("empty export - not implemented")
+	}
+
+	manifest := ociManifest{
+		SchemaVersion: ociSchemaVersion,
+		MediaType:     ociManifestType,
+	}
+	for i := range img.RootFS.DiffIDs {
+		rootFS := *img.RootFS
+		rootFS.DiffIDs = rootFS.DiffIDs[:i+1]
+		desc, err := s.saveOCILayer(rootFS.ChainID())
+		if err != nil {
+			return ociDescriptor{}, err
+		}
+		manifest.Layers = append(manifest.Layers, desc)
+	}
+
+	if manifest.Config, err = s.writeBlob(ociConfigType, img.RawJSON()); err != nil {
+		return ociDescriptor{}, err
+	}
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	desc, err := s.writeBlob(ociManifestType, data)
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	desc.Platform = &ociPlatform{
+		Architecture: img.Architecture,
+		OS:           img.OS,
+	}
+	return desc, nil
+}
+
+func (s *saveSession) saveOCILayer(id layer.ChainID) (ociDescriptor, error) {
+	if desc, exists := s.savedOCILayers[id]; exists {
+		return desc, nil
+	}
+
+	l, err := s.ls.Get(id)
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	defer layer.ReleaseAndLog(s.ls, l)
+
+	arch, err := l.TarStream()
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	defer arch.Close()
+
+	// The digest of the layer is only known once it is written, so it is
+	// written to a temporary file which is then renamed to its blob.
+	blobsDir := filepath.Join(s.outDir, ociBlobsDirName, string(digest.Canonical))
+	tarFile, err := ioutil.TempFile(blobsDir, "layer-")
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	defer tarFile.Close()
+
+	digester := digest.Canonical.New()
+	size, err := io.Copy(io.MultiWriter(tarFile, digester.Hash()), arch)
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	if err := tarFile.Close(); err != nil {
+		return ociDescriptor{}, err
+	}
+
+	desc := ociDescriptor{
+		MediaType: ociLayerType,
+		Digest:    digester.Digest(),
+		Size:      size,
+	}
+	blobFile := filepath.Join(blobsDir, desc.Digest.Hex())
+	if err := os.Rename(tarFile.Name(), blobFile); err != nil {
+		return ociDescriptor{}, err
+	}
+	if err := os.Chmod(blobFile, 0644); err != nil {
+		return ociDescriptor{}, err
+	}
+	if err := system.Chtimes(blobFile, time.Unix(0, 0), time.Unix(0, 0)); err != nil {
+		return ociDescriptor{}, err
+	}
+
+	s.savedOCILayers[id] = desc
+	return desc, nil
+}
+
+// writeBlob writes data as a blob and returns its descriptor.
+func (s *saveSession) writeBlob(mediaType string, data []byte) (ociDescriptor, error) {
+	desc := ociDescriptor{
+		MediaType: mediaType,
+		Digest:    digest.FromBytes(data),
+		Size:      int64(len(data)),
+	}
+	blobFile := filepath.Join(s.outDir, ociBlobsDirName, string(desc.Digest.Algorithm()), desc.Digest.Hex())
+	if err := ioutil.WriteFile(blobFile, data, 0644); err != nil {
+		return ociDescriptor{}, err
+	}
+	if err := system.Chtimes(blobFile, time.Unix(0, 0), time.Unix(0, 0)); err != nil {
+		return ociDescriptor{}, err
+	}
+	return desc, nil
+}
+
+func writeJSONFile(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return err
+	}
+	return system.Chtimes(path, time.Unix(0, 0), time.Unix(0, 0))
+}
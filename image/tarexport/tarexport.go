@@ -1,6 +1,7 @@
 package tarexport
 
 import (
+	"github.com/docker/distribution/digest"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
 	"github.com/docker/docker/reference"
@@ -12,6 +13,27 @@ const (
 	legacyConfigFileName       = "json"
 	legacyVersionFileName      = "VERSION"
 	legacyRepositoriesFileName = "repositories"
+
+	ociLayoutFileName   = "oci-layout"
+	ociIndexFileName    = "index.json"
+	ociBlobsDirName     = "blobs"
+	ociLayoutVersion    = "1.0.0"
+	ociSchemaVersion    = 2
+	ociRefNameKey       = "org.opencontainers.image.ref.name"
+	ociImageNameKey     = "io.containerd.image.name"
+	ociIndexMediaType   = "application/vnd.oci.image.index.v1+json"
+	ociManifestType     = "application/vnd.oci.image.manifest.v1+json"
+	ociConfigType       = "application/vnd.oci.image.config.v1+json"
+	ociLayerType        = "application/vnd.oci.image.layer.v1.tar"
+	ociLayerGzipType    = "application/vnd.oci.image.layer.v1.tar+gzip"
+	dockerConfigType    = "application/vnd.docker.container.image.v1+json"
+	dockerLayerGzipType = "application/vnd.docker.image.rootfs.diff.tar.gzip"
+)
+
+// Formats of the archives written by Save.
+const (
+	formatDocker = "docker"
+	formatOCI    = "oci"
 )
 
 type manifestItem struct {
@@ -21,6 +43,40 @@ type manifestItem struct {
 	Parent   image.ID `json:",omitempty"`
 }
 
+// ociLayout is the content of the oci-layout file of an OCI image layout.
+type ociLayout struct {
+	ImageLayoutVersion string `json:"imageLayoutVersion"`
+}
+
+// ociDescriptor describes a blob of an OCI image layout.
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      digest.Digest     `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Platform    *ociPlatform      `json:"platform,omitempty"`
+}
+
+type ociPlatform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+}
+
+// ociIndex is the content of index.json and of the image index blobs.
+type ociIndex struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	MediaType     string          `json:"mediaType,omitempty"`
+	Manifests     []ociDescriptor `json:"manifests"`
+}
+
+// ociManifest is the content of an image manifest blob.
+type ociManifest struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	MediaType     string          `json:"mediaType,omitempty"`
+	Config        ociDescriptor   `json:"config"`
+	Layers        []ociDescriptor `json:"layers"`
+}
+
 type tarexporter struct {
 	is image.Store
 	ls layer.Store
@@ -0,0 +1,87 @@
+package tarexport
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/daemon/graphdriver"
+	_ "github.com/docker/docker/daemon/graphdriver/vfs"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/reference"
+)
+
+func newStores(t *testing.T, td string) *tarexporter {
+	driver, err := graphdriver.GetDriver("vfs", filepath.Join(td, "graph"), nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fms, err := layer.NewFSMetadataStore(filepath.Join(td, "layers"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ls, err := layer.NewStoreFromGraphDriver(fms, driver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs, err := image.NewFSStoreBackend(filepath.Join(td, "images"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	is, err := image.NewImageStore(fs, ls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs, err := reference.NewReferenceStore(filepath.Join(td, "repos.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewTarExporter(is, ls, rs).(*tarexporter)
+}
+
+func TestTmpOCI(t *testing.T) {
+	td, _ := ioutil.TempDir("", "oci")
+	defer os.RemoveAll(td)
+	src := newStores(t, filepath.Join(td, "a"))
+	dir := filepath.Join(td, "content")
+	os.MkdirAll(dir, 0755)
+	ioutil.WriteFile(filepath.Join(dir, "hello"), []byte("hi"), 0644)
+	tr, _ := archive.Tar(dir, archive.Uncompressed)
+	l, err := src.ls.Register(tr, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := []byte(`{"architecture":"amd64","os":"linux","rootfs":{"type":"layers","diff_ids":["` + l.DiffID().String() + `"]}}`)
+	id, err := src.is.Create(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	named, _ := reference.ParseNamed("foo/bar:baz")
+	src.rs.AddTag(named.(reference.NamedTagged), id, true)
+
+	buf := &bytes.Buffer{}
+	if err := src.Save([]string{"foo/bar:baz"}, buf, "oci"); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(td, "out")
+	archive.Untar(bytes.NewReader(buf.Bytes()), out, &archive.TarOptions{NoLchown: true})
+	filepath.Walk(out, func(p string, fi os.FileInfo, err error) error { t.Log(p, fi.Mode()); return nil })
+	idx, _ := ioutil.ReadFile(filepath.Join(out, "index.json"))
+	t.Log(string(idx))
+
+	dst := newStores(t, filepath.Join(td, "b"))
+	if err := dst.Load(ioutil.NopCloser(bytes.NewReader(buf.Bytes())), ioutil.Discard, true); err != nil {
+		t.Fatal(err)
+	}
+	got, err := dst.rs.Get(named)
+	if err != nil || got != id {
+		t.Fatal(got, err)
+	}
+	if err := src.Save([]string{"foo/bar:baz"}, buf, "bad"); err == nil {
+		t.Fatal("expected error")
+	}
+}
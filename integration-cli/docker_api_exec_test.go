@@ -181,3 +181,46 @@ func inspectExec(c *check.C, id string, out interface{}) {
 	err = json.NewDecoder(body).Decode(out)
 	c.Assert(err, checker.IsNil)
 }
+
+func (s *DockerSuite) TestExecApiKill(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	name := "exec_kill_test"
+	runSleepingContainer(c, "-d", "--name", name)
+	c.Assert(waitRun(name), checker.IsNil)
+
+	_, b, err := sockRequest("POST", fmt.Sprintf("/containers/%s/exec", name), map[string]interface{}{"Cmd": []string{"sleep", "60"}})
+	c.Assert(err, checker.IsNil, check.Commentf(string(b)))
+	createResp := struct {
+		ID string `json:"Id"`
+	}{}
+	c.Assert(json.Unmarshal(b, &createResp), checker.IsNil, check.Commentf(string(b)))
+	id := createResp.ID
+
+	// The exec is not running yet
+	status, body, err := sockRequest("POST", fmt.Sprintf("/exec/%s/kill", id), nil)
+	c.Assert(err, checker.IsNil)
+	c.Assert(status, checker.Equals, http.StatusConflict, check.Commentf(string(body)))
+
+	startExec(c, id, http.StatusOK)
+
+	status, body, err = sockRequest("POST", fmt.Sprintf("/exec/%s/kill?signal=TERM", id), nil)
+	c.Assert(err, checker.IsNil)
+	c.Assert(status, checker.Equals, http.StatusNoContent, check.Commentf(string(body)))
+
+	var inspectJSON struct {
+		Running  bool
+		ExitCode int
+		TimedOut bool
+	}
+	tries := 10
+	for i := 0; i < tries; i++ {
+		inspectExec(c, id, &inspectJSON)
+		if !inspectJSON.Running {
+			break
+		}
+		c.Assert(i+1, checker.Not(checker.Equals), tries, check.Commentf("exec still running after 10 seconds"))
+		time.Sleep(1 * time.Second)
+	}
+	c.Assert(inspectJSON.ExitCode, checker.Equals, 143)
+	c.Assert(inspectJSON.TimedOut, checker.False)
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 
 	"github.com/docker/docker/pkg/integration/checker"
@@ -66,6 +68,25 @@ func (s *DockerSuite) TestCommitNewFile(c *check.C) {
 	c.Assert(actual, checker.Equals, "koye")
 }
 
+func (s *DockerSuite) TestCommitExclude(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	dockerCmd(c, "run", "--name", "commit-exclude", "busybox", "/bin/sh", "-c",
+		"mkdir -p /cache /data && echo cache > /cache/file && echo data > /data/file && echo sock > /data/app.sock && echo keep > /data/keep.sock")
+
+	ignoreFile := filepath.Join(c.MkDir(), "commit-ignore")
+	err := ioutil.WriteFile(ignoreFile, []byte("/cache\n/data/*.sock\n"), 0644)
+	c.Assert(err, checker.IsNil)
+
+	imageID, _ := dockerCmd(c, "commit", "--exclude-from", ignoreFile, "--exclude", "!/data/keep.sock", "commit-exclude")
+	imageID = strings.TrimSpace(imageID)
+
+	out, _ := dockerCmd(c, "run", "--rm", imageID, "/bin/sh", "-c", "ls /cache /data/app.sock; cat /data/file /data/keep.sock")
+	c.Assert(out, checker.Contains, "No such file or directory")
+	c.Assert(out, checker.Not(checker.Contains), "cache\n")
+	c.Assert(out, checker.Not(checker.Contains), "sock\n")
+	c.Assert(out, checker.Contains, "data\nkeep\n")
+}
+
 func (s *DockerSuite) TestCommitHardlink(c *check.C) {
 	testRequires(c, DaemonIsLinux)
 	firstOutput, _ := dockerCmd(c, "run", "-t", "--name", "hardlinks", "busybox", "sh", "-c", "touch file1 && ln file1 file2 && ls -di file1 file2")
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/docker/docker/pkg/integration/checker"
+	"github.com/go-check/check"
+)
+
+// docker cp CONTAINER:PATH CONTAINER:PATH
+
+// Copy a file from a container to a file in another container.
+func (s *DockerSuite) TestCpBetweenContainersFile(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	srcID := makeTestContainer(c, testContainerOptions{addContent: true})
+	dstID := makeTestContainer(c, testContainerOptions{
+		command: makeCatFileCommand("file1-copy"),
+	})
+
+	srcPath := containerCpPath(srcID, "/file1")
+	dstPath := containerCpPath(dstID, "/file1-copy")
+
+	c.Assert(runDockerCp(c, srcPath, dstPath), checker.IsNil)
+
+	c.Assert(containerStartOutputEquals(c, dstID, "file1\n"), checker.IsNil)
+}
+
+// Copy a directory from a container into an existing directory of another
+// container.
+func (s *DockerSuite) TestCpBetweenContainersDir(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	srcID := makeTestContainer(c, testContainerOptions{addContent: true})
+	dstID := makeTestContainer(c, testContainerOptions{
+		addContent: true,
+		command:    makeCatFileCommand("dir5/dir1/file1-1"),
+	})
+
+	srcPath := containerCpPath(srcID, "/dir1")
+	dstPath := containerCpPath(dstID, "/dir5")
+
+	c.Assert(runDockerCp(c, srcPath, dstPath), checker.IsNil)
+
+	c.Assert(containerStartOutputEquals(c, dstID, "file1-1\n"), checker.IsNil)
+}
+
+// Copy the target of a symlink from a container to another container, it is
+// copied with the name of the link.
+func (s *DockerSuite) TestCpBetweenContainersFollowLink(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	srcID := makeTestContainer(c, testContainerOptions{addContent: true})
+	dstID := makeTestContainer(c, testContainerOptions{
+		command: makeCatFileCommand("symlinkToFile1"),
+	})
+
+	srcPath := containerCpPath(srcID, "/symlinkToFile1")
+	dstPath := containerCpPath(dstID, "/")
+
+	out, _, err := dockerCmdWithError("cp", "-L", srcPath, dstPath)
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+
+	c.Assert(containerStartOutputEquals(c, dstID, "file1\n"), checker.IsNil)
+}
+
+// Copy a file with its ownership from a container to another container.
+func (s *DockerSuite) TestCpBetweenContainersArchive(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	srcID := makeTestContainer(c, testContainerOptions{
+		addContent: true,
+		command:    "chown 1234:5678 file1",
+	})
+	dstID := makeTestContainer(c, testContainerOptions{
+		command: "if [ -f file1 ]; then stat -c %u:%g file1; fi",
+	})
+
+	srcPath := containerCpPath(srcID, "/file1")
+	dstPath := containerCpPath(dstID, "/")
+
+	out, _, err := dockerCmdWithError("cp", "-a", srcPath, dstPath)
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+
+	out, err = startContainerGetOutput(c, dstID)
+	c.Assert(err, checker.IsNil)
+	c.Assert(strings.TrimSpace(out), checker.Equals, "1234:5678")
+}
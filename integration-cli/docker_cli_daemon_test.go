@@ -29,6 +29,27 @@ import (
 	"github.com/kr/pty"
 )
 
+func (s *DockerDaemonSuite) TestDaemonEphemeralPortRange(c *check.C) {
+	testRequires(c, DaemonIsLinux, NotUserNamespace)
+	c.Assert(s.d.StartWithBusybox("--ephemeral-port-range", "40100-40101"), check.IsNil)
+
+	out, err := s.d.Cmd("run", "-d", "--name", "top1", "-p", "80", "busybox", "top")
+	c.Assert(err, check.IsNil, check.Commentf(out))
+	out, err = s.d.Cmd("port", "top1", "80")
+	c.Assert(err, check.IsNil, check.Commentf(out))
+	_, port, err := net.SplitHostPort(strings.TrimSpace(out))
+	c.Assert(err, check.IsNil, check.Commentf(out))
+	c.Assert(port == "40100" || port == "40101", check.Equals, true, check.Commentf("port %s is out of the range", port))
+
+	// The port is kept when the daemon restarts
+	c.Assert(s.d.Restart("--ephemeral-port-range", "40100-40101"), check.IsNil)
+	out, err = s.d.Cmd("start", "top1")
+	c.Assert(err, check.IsNil, check.Commentf(out))
+	out, err = s.d.Cmd("port", "top1", "80")
+	c.Assert(err, check.IsNil, check.Commentf(out))
+	c.Assert(strings.TrimSpace(out), checker.HasSuffix, ":"+port)
+}
+
 func (s *DockerDaemonSuite) TestDaemonRestartWithRunningContainersPorts(c *check.C) {
 	if err := s.d.StartWithBusybox(); err != nil {
 		c.Fatalf("Could not start daemon with busybox: %v", err)
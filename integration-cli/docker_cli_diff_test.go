@@ -85,3 +85,16 @@ func (s *DockerSuite) TestDiffEmptyArgClientError(c *check.C) {
 	c.Assert(err, checker.NotNil)
 	c.Assert(strings.TrimSpace(out), checker.Equals, "Container name cannot be empty")
 }
+
+func (s *DockerSuite) TestDiffDetails(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	out, _ := dockerCmd(c, "run", "-d", "busybox", "sh", "-c", "echo foo > /root/bar && chmod 600 /root/bar && rm /etc/group")
+	cleanCID := strings.TrimSpace(out)
+	dockerCmd(c, "wait", cleanCID)
+
+	out, _ = dockerCmd(c, "diff", "--details", cleanCID)
+	// sha256 of "foo\n"
+	c.Assert(out, checker.Contains, "A -rw------- 4 b5bb9d8014a0f9b1d61e21e796d78dccdf1352f23cd32812f4850b878ae4944c /root/bar")
+	c.Assert(out, checker.Matches, `(?s).*C d[rwx-]{9} [0-9]+ - /root\n.*`)
+	c.Assert(out, checker.Contains, "D - - - /etc/group")
+}
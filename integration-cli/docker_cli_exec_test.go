@@ -4,6 +4,7 @@ package main
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -509,3 +510,54 @@ func (s *DockerSuite) TestExecStartFails(c *check.C) {
 	c.Assert(err, checker.NotNil, check.Commentf(out))
 	c.Assert(out, checker.Contains, "executable file not found")
 }
+
+func (s *DockerSuite) TestExecWithEnvAndWorkdir(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	runSleepingContainer(c, "-d", "-e", "LALA=value1", "-e", "KEEP=value", "--name", "testing")
+	c.Assert(waitRun("testing"), check.IsNil)
+
+	out, _ := dockerCmd(c, "exec", "-e", "LALA=value2", "-w", "/tmp", "testing", "sh", "-c", "env; pwd")
+	c.Assert(out, checker.Not(checker.Contains), "LALA=value1")
+	c.Assert(out, checker.Contains, "LALA=value2")
+	c.Assert(out, checker.Contains, "KEEP=value")
+	c.Assert(out, checker.Contains, "/tmp\n")
+
+	out, _, err := dockerCmdWithError("exec", "-w", "tmp", "testing", "pwd")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "It needs to be an absolute path")
+}
+
+func (s *DockerSuite) TestExecTimeout(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	runSleepingContainer(c, "-d", "--name", "testing")
+	c.Assert(waitRun("testing"), check.IsNil)
+
+	dockerCmd(c, "exec", "-d", "--timeout", "1", "testing", "sleep", "60")
+	out, _ := dockerCmd(c, "inspect", "--format", "{{json .ExecIDs}}", "testing")
+	var ids []string
+	c.Assert(json.Unmarshal([]byte(out), &ids), checker.IsNil)
+	c.Assert(ids, checker.HasLen, 1)
+
+	var inspectJSON struct {
+		Running  bool
+		ExitCode int
+		TimedOut bool
+	}
+	// Give the exec 10 chances/seconds to be killed then give up and stop the test
+	tries := 10
+	for i := 0; i < tries; i++ {
+		inspectExec(c, ids[0], &inspectJSON)
+		if !inspectJSON.Running {
+			break
+		}
+		c.Assert(i+1, checker.Not(checker.Equals), tries, check.Commentf("exec still running after 10 seconds"))
+		time.Sleep(1 * time.Second)
+	}
+	c.Assert(inspectJSON.TimedOut, checker.True)
+	c.Assert(inspectJSON.ExitCode, checker.Equals, 137)
+
+	// The exec can still be inspected once the container is stopped.
+	dockerCmd(c, "stop", "testing")
+	inspectExec(c, ids[0], &inspectJSON)
+	c.Assert(inspectJSON.ExitCode, checker.Equals, 137)
+}
@@ -65,6 +65,17 @@ func (s *DockerSuite) TestHistoryExistentImage(c *check.C) {
 	dockerCmd(c, "history", "busybox")
 }
 
+func (s *DockerSuite) TestHistoryFormat(c *check.C) {
+	out, _ := dockerCmd(c, "history", "-q", "--no-trunc", "busybox")
+	ids := strings.Split(strings.TrimSpace(out), "\n")
+
+	out, _ = dockerCmd(c, "history", "--no-trunc", "--format", "{{.ID}}", "busybox")
+	c.Assert(strings.Split(strings.TrimSpace(out), "\n"), checker.DeepEquals, ids)
+
+	out, _ = dockerCmd(c, "history", "--format", "table {{.ID}}\t{{.Size}}", "busybox")
+	c.Assert(strings.Fields(strings.Split(out, "\n")[0]), checker.DeepEquals, []string{"IMAGE", "SIZE"})
+}
+
 func (s *DockerSuite) TestHistoryNonExistentImage(c *check.C) {
 	_, _, err := dockerCmdWithError("history", "testHistoryNonExistentImage")
 	c.Assert(err, checker.NotNil, check.Commentf("history on a non-existent image should fail."))
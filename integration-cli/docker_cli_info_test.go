@@ -45,6 +45,19 @@ func (s *DockerSuite) TestInfoEnsureSucceeds(c *check.C) {
 
 // TestInfoDiscoveryBackend verifies that a daemon run with `--cluster-advertise` and
 // `--cluster-store` properly show the backend's endpoint in info output.
+func (s *DockerSuite) TestInfoFormat(c *check.C) {
+	version, _ := dockerCmd(c, "version", "--format", "{{.Server.Version}}")
+	out, _ := dockerCmd(c, "info", "--format", "{{.ServerVersion}}")
+	c.Assert(strings.TrimSpace(out), checker.Equals, strings.TrimSpace(version))
+
+	out, _ = dockerCmd(c, "info", "--format", "json")
+	c.Assert(strings.TrimSpace(out), checker.HasPrefix, "{")
+
+	out, _, err := dockerCmdWithError("info", "--format", "table {{.ID}}")
+	c.Assert(err, checker.NotNil, check.Commentf(out))
+	c.Assert(out, checker.Contains, "the table format is not supported by info")
+}
+
 func (s *DockerSuite) TestInfoDiscoveryBackend(c *check.C) {
 	testRequires(c, SameHostDaemon, DaemonIsLinux)
 
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"os/exec"
 	"strings"
 	"time"
 
@@ -279,6 +280,14 @@ func (s *DockerNetworkSuite) TestDockerNetworkLsDefault(c *check.C) {
 	}
 }
 
+func (s *DockerNetworkSuite) TestDockerNetworkLsFormat(c *check.C) {
+	out, _ := dockerCmd(c, "network", "ls", "--format", "{{.Name}}: {{.Driver}}")
+	c.Assert(strings.Split(strings.TrimSpace(out), "\n"), checker.DeepEquals, []string{"bridge: bridge", "host: host", "none: null"})
+
+	out, _ = dockerCmd(c, "network", "ls", "--format", "table {{.Name}}\t{{.Scope}}")
+	c.Assert(strings.Fields(strings.Split(out, "\n")[0]), checker.DeepEquals, []string{"NAME", "SCOPE"})
+}
+
 func (s *DockerNetworkSuite) TestDockerNetworkCreatePredefined(c *check.C) {
 	predefined := []string{"bridge", "host", "none", "default"}
 	for _, net := range predefined {
@@ -707,6 +716,41 @@ func (s *DockerNetworkSuite) TestDockerNetworkDriverOptions(c *check.C) {
 
 }
 
+func (s *DockerNetworkSuite) TestDockerNetworkBridgePolicy(c *check.C) {
+	testRequires(c, SameHostDaemon, NotUserNamespace)
+
+	out, _, err := dockerCmdWithError("network", "create", "-o", "com.docker.network.bridge.policy=permit,egress", "testpolicy")
+	c.Assert(err, checker.NotNil, check.Commentf(out))
+	c.Assert(out, checker.Contains, "the action must be allow or deny")
+
+	out, _ = dockerCmd(c, "network", "create", "-o", "com.docker.network.bridge.policy=allow,egress,port=53/udp;allow,ingress,label=role=frontend;deny,ingress", "testpolicy")
+	nid := strings.TrimSpace(out)
+	assertNwIsAvailable(c, "testpolicy")
+	chain := "DOCKER-POLICY-" + stringid.TruncateID(nid)
+
+	rules, _, err := runCommandWithOutput(exec.Command("iptables", "-S", chain))
+	c.Assert(err, checker.IsNil, check.Commentf(rules))
+	c.Assert(rules, checker.Contains, "-p udp -m udp --dport 53 -j RETURN")
+	c.Assert(rules, checker.Contains, "-j DROP")
+
+	// The rules matching containers by label follow the containers
+	dockerCmd(c, "run", "-d", "--net=testpolicy", "--name=frontend", "--label", "role=frontend", "busybox", "top")
+	ip := inspectField(c, "frontend", "NetworkSettings.Networks.testpolicy.IPAddress")
+	rules, _, err = runCommandWithOutput(exec.Command("iptables", "-S", chain))
+	c.Assert(err, checker.IsNil, check.Commentf(rules))
+	c.Assert(rules, checker.Contains, "-s "+ip+"/32")
+
+	dockerCmd(c, "network", "disconnect", "testpolicy", "frontend")
+	rules, _, err = runCommandWithOutput(exec.Command("iptables", "-S", chain))
+	c.Assert(err, checker.IsNil, check.Commentf(rules))
+	c.Assert(rules, checker.Not(checker.Contains), "-s "+ip+"/32")
+
+	dockerCmd(c, "network", "rm", "testpolicy")
+	assertNwNotAvailable(c, "testpolicy")
+	_, _, err = runCommandWithOutput(exec.Command("iptables", "-S", chain))
+	c.Assert(err, checker.NotNil)
+}
+
 func (s *DockerDaemonSuite) TestDockerNetworkNoDiscoveryDefaultBridgeNetwork(c *check.C) {
 	testRequires(c, ExecSupport)
 	// On default bridge network built-in service discovery should not happen
@@ -294,6 +294,21 @@ func (s *DockerSuite) TestPortExposeHostBinding(c *check.C) {
 	c.Assert(err, checker.NotNil, check.Commentf("out: %s", out))
 }
 
+func (s *DockerSuite) TestPortPublishedPortKeptOnRestart(c *check.C) {
+	testRequires(c, DaemonIsLinux, NotUserNamespace)
+	out, _ := dockerCmd(c, "run", "-d", "-P", "--expose", "80", "-p", "9870-9880:81", "busybox", "top")
+	id := strings.TrimSpace(out)
+
+	out, _ = dockerCmd(c, "port", id)
+	before := strings.TrimSpace(out)
+	c.Assert(inspectField(c, id, "HostConfig.PublishedPorts"), checker.Not(checker.Equals), "map[]")
+
+	dockerCmd(c, "restart", id)
+
+	out, _ = dockerCmd(c, "port", id)
+	c.Assert(strings.TrimSpace(out), checker.Equals, before, check.Commentf("the published ports changed on restart"))
+}
+
 func (s *DockerSuite) TestPortBindingOnSandbox(c *check.C) {
 	testRequires(c, DaemonIsLinux, NotUserNamespace)
 	dockerCmd(c, "network", "create", "--internal", "-d", "bridge", "internal-net")
@@ -2871,6 +2871,42 @@ func (s *DockerSuite) TestRunContainerWithRmFlagCannotStartContainer(c *check.C)
 	}
 }
 
+func (s *DockerSuite) TestRunDetachedWithRmFlag(c *check.C) {
+	name := "butterflies"
+	dockerCmd(c, "run", "-d", "--name", name, "--rm", "busybox", "true")
+
+	// The daemon removes the container once it exits.
+	timeout := time.After(30 * time.Second)
+	for {
+		out, err := getAllContainers()
+		c.Assert(err, checker.IsNil)
+		if out == "" {
+			break
+		}
+		select {
+		case <-timeout:
+			c.Fatalf("Expected container %s to be removed, got %s", name, out)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+func (s *DockerSuite) TestRunWithRmFlagExitCode(c *check.C) {
+	_, exitCode, err := dockerCmdWithError("run", "--rm", "busybox", "sh", "-c", "exit 3")
+	c.Assert(err, checker.NotNil)
+	c.Assert(exitCode, checker.Equals, 3)
+
+	out, err := getAllContainers()
+	c.Assert(err, checker.IsNil)
+	c.Assert(out, checker.Equals, "")
+}
+
+func (s *DockerSuite) TestRunRmFlagAndRestartPolicyConflict(c *check.C) {
+	out, _, err := dockerCmdWithError("run", "--rm", "--restart", "always", "busybox", "true")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "Conflicting options: --restart and --rm")
+}
+
 func (s *DockerSuite) TestRunPidHostWithChildIsKillable(c *check.C) {
 	// Not applicable on Windows as uses Unix specific functionality
 	testRequires(c, DaemonIsLinux, NotUserNamespace)
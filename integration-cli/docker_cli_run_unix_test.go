@@ -408,6 +408,22 @@ func (s *DockerSuite) TestRunWithCPUQuota(c *check.C) {
 	c.Assert(out, checker.Equals, "8000", check.Commentf("setting the CPU CFS quota failed"))
 }
 
+func (s *DockerSuite) TestRunWithNanoCPUs(c *check.C) {
+	testRequires(c, cpuCfsQuota, cpuCfsPeriod)
+
+	file1 := "/sys/fs/cgroup/cpu/cpu.cfs_quota_us"
+	file2 := "/sys/fs/cgroup/cpu/cpu.cfs_period_us"
+	out, _ := dockerCmd(c, "run", "--cpus", "0.5", "--name", "test", "busybox", "sh", "-c", fmt.Sprintf("cat %s && cat %s", file1, file2))
+	c.Assert(strings.TrimSpace(out), checker.Equals, "50000\n100000")
+
+	out = inspectField(c, "test", "HostConfig.NanoCpus")
+	c.Assert(out, checker.Equals, "500000000", check.Commentf("setting the Nano CPUs failed"))
+
+	out, _, err := dockerCmdWithError("run", "--cpus", "0.5", "--cpu-quota", "50000", "busybox", "true")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "Conflicting options: Nano CPUs and CPU Quota cannot both be set")
+}
+
 func (s *DockerSuite) TestRunWithCpuPeriod(c *check.C) {
 	testRequires(c, cpuCfsPeriod)
 
@@ -748,6 +764,48 @@ func (s *DockerSuite) TestRunTmpfsMounts(c *check.C) {
 	}
 }
 
+func (s *DockerSuite) TestRunMountTmpfs(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	out, _ := dockerCmd(c, "run", "--mount", "type=tmpfs,target=/run,tmpfs-size=5k,tmpfs-mode=700", "busybox", "grep", "/run", "/proc/mounts")
+	c.Assert(out, checker.Contains, "tmpfs /run tmpfs")
+	c.Assert(out, checker.Contains, "size=8k")
+	c.Assert(out, checker.Contains, "mode=700")
+
+	out, _, err := dockerCmdWithError("run", "--mount", "type=tmpfs,target=/run", "--tmpfs", "/run", "busybox", "true")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "Duplicate mount point")
+}
+
+func (s *DockerSuite) TestRunMountBindWithColon(c *check.C) {
+	testRequires(c, DaemonIsLinux, SameHostDaemon)
+	tmpDir, err := ioutil.TempDir("", "docker-run-mount-bind")
+	c.Assert(err, checker.IsNil)
+	defer os.RemoveAll(tmpDir)
+
+	source := filepath.Join(tmpDir, "with:colon")
+	c.Assert(os.Mkdir(source, 0755), checker.IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(source, "foo"), []byte("bar"), 0644), checker.IsNil)
+
+	out, _ := dockerCmd(c, "run", "--mount", "type=bind,source="+source+",target=/with:colon,readonly", "busybox", "cat", "/with:colon/foo")
+	c.Assert(out, checker.Equals, "bar")
+
+	out, _, err = dockerCmdWithError("run", "--mount", "type=bind,source="+source+",target=/with:colon,readonly", "busybox", "touch", "/with:colon/foo")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "Read-only file system")
+
+	out, _, err = dockerCmdWithError("run", "--mount", "type=bind,source="+filepath.Join(tmpDir, "missing")+",target=/foo", "busybox", "true")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "bind source path does not exist")
+}
+
+func (s *DockerSuite) TestRunMountVolumeWithLabels(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	dockerCmd(c, "run", "--mount", "type=volume,source=mountvolume,target=/foo,volume-label=foo=bar", "busybox", "true")
+
+	out, _ := dockerCmd(c, "volume", "inspect", "--format", "{{ .Labels.foo }}", "mountvolume")
+	c.Assert(strings.TrimSpace(out), checker.Equals, "bar")
+}
+
 func (s *DockerSuite) TestRunSysctls(c *check.C) {
 
 	testRequires(c, DaemonIsLinux)
@@ -1076,3 +1134,15 @@ func (s *DockerSuite) TestRunUserDeviceAllowed(c *check.C) {
 	out, _ := dockerCmd(c, "run", "--device", "/dev/snd/timer:w", "busybox", "cat", file)
 	c.Assert(out, checker.Contains, fmt.Sprintf("c %d:%d w", stat.Rdev/256, stat.Rdev%256))
 }
+
+func (s *DockerSuite) TestRunDeviceCgroupRule(c *check.C) {
+	testRequires(c, DaemonIsLinux, NotUserNamespace)
+
+	file := "/sys/fs/cgroup/devices/devices.list"
+	out, _ := dockerCmd(c, "run", "--device-cgroup-rule", "c 7:* m", "busybox", "cat", file)
+	c.Assert(out, checker.Contains, "c 7:* m")
+
+	out, _, err := dockerCmdWithError("run", "--device-cgroup-rule", "c 7:x m", "busybox", "true")
+	c.Assert(err, checker.NotNil, check.Commentf(out))
+	c.Assert(out, checker.Contains, "invalid device cgroup rule")
+}
@@ -350,3 +350,56 @@ func (s *DockerSuite) TestSaveLoadParents(c *check.C) {
 	inspectOut = inspectField(c, idFoo, "Parent")
 	c.Assert(inspectOut, checker.Equals, "")
 }
+
+func (s *DockerSuite) TestSaveAndLoadOCI(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	name := "test-save-and-load-oci"
+	dockerCmd(c, "run", "--name", name, "busybox", "touch", "/foo")
+
+	repoName := "foobar-save-load-oci"
+
+	deleteImages(repoName)
+	dockerCmd(c, "commit", name, repoName)
+
+	before, _ := dockerCmd(c, "inspect", repoName)
+
+	tmpDir, err := ioutil.TempDir("", "save-load-oci")
+	c.Assert(err, checker.IsNil)
+	defer os.RemoveAll(tmpDir)
+
+	outfile := filepath.Join(tmpDir, "out.tar")
+	dockerCmd(c, "save", "--format", "oci", "-o", outfile, repoName)
+
+	out, _, err := runCommandWithOutput(exec.Command("tar", "tf", outfile))
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+	c.Assert(out, checker.Contains, "oci-layout")
+	c.Assert(out, checker.Contains, "index.json")
+	c.Assert(out, checker.Contains, "blobs/sha256/")
+	c.Assert(out, checker.Not(checker.Contains), "manifest.json")
+
+	out, _, err = runCommandWithOutput(exec.Command("tar", "xOf", outfile, "index.json"))
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+	var index struct {
+		Manifests []struct {
+			MediaType   string
+			Annotations map[string]string
+		}
+	}
+	c.Assert(json.Unmarshal([]byte(out), &index), checker.IsNil)
+	c.Assert(index.Manifests, checker.HasLen, 1)
+	c.Assert(index.Manifests[0].MediaType, checker.Equals, "application/vnd.oci.image.manifest.v1+json")
+	c.Assert(index.Manifests[0].Annotations["org.opencontainers.image.ref.name"], checker.Equals, "latest")
+
+	deleteImages(repoName)
+	dockerCmd(c, "load", "-i", outfile)
+
+	after, _ := dockerCmd(c, "inspect", repoName)
+	c.Assert(before, checker.Equals, after, check.Commentf("inspect is not the same after a save / load"))
+}
+
+func (s *DockerSuite) TestSaveInvalidFormat(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	out, _, err := dockerCmdWithError("save", "--format", "foo", "-o", "test-img.tar", "busybox")
+	c.Assert(err, checker.NotNil, check.Commentf("save should fail with an invalid format"))
+	c.Assert(out, checker.Contains, `invalid format "foo"`)
+}
@@ -1,6 +1,9 @@
 package main
 
 import (
+	"strings"
+	"time"
+
 	"github.com/docker/docker/pkg/integration/checker"
 	"github.com/go-check/check"
 )
@@ -15,3 +18,23 @@ func (s *DockerSuite) TestStopContainerWithRestartPolicyAlways(c *check.C) {
 	dockerCmd(c, "stop", "verifyRestart1")
 	dockerCmd(c, "stop", "verifyRestart2")
 }
+
+func (s *DockerSuite) TestStopContainerWithStopTimeout(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	// sleep is PID 1 and does not handle SIGTERM, so it is only stopped
+	// once the stop timeout expires.
+	dockerCmd(c, "run", "--name", "verifyStopTimeout", "-d", "--stop-timeout=2", "busybox", "sleep", "300")
+	c.Assert(waitRun("verifyStopTimeout"), checker.IsNil)
+
+	out := inspectField(c, "verifyStopTimeout", "Config.StopTimeout")
+	c.Assert(strings.TrimSpace(out), checker.Equals, "2")
+
+	start := time.Now()
+	dockerCmd(c, "stop", "verifyStopTimeout")
+	elapsed := time.Since(start)
+	c.Assert(elapsed >= 2*time.Second, checker.True, check.Commentf("stopped after %s, before the stop timeout", elapsed))
+	c.Assert(elapsed < 10*time.Second, checker.True, check.Commentf("stopped after %s, after the default timeout", elapsed))
+
+	out = inspectField(c, "verifyStopTimeout", "State.ExitCode")
+	c.Assert(out, checker.Equals, "137")
+}
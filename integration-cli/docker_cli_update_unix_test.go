@@ -5,6 +5,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"os/exec"
 	"strings"
 
 	"github.com/docker/docker/pkg/integration/checker"
@@ -215,3 +216,54 @@ func (s *DockerSuite) TestUpdateStats(c *check.C) {
 	c.Assert(preMemLimit, checker.Equals, curMemLimit)
 
 }
+
+func (s *DockerSuite) TestUpdateNanoCPUs(c *check.C) {
+	testRequires(c, cpuCfsQuota, cpuCfsPeriod)
+
+	file1 := "/sys/fs/cgroup/cpu/cpu.cfs_quota_us"
+	file2 := "/sys/fs/cgroup/cpu/cpu.cfs_period_us"
+
+	out, _ := dockerCmd(c, "run", "-d", "--cpus", "0.5", "--name", "top", "busybox", "top")
+	c.Assert(strings.TrimSpace(out), checker.Not(checker.Equals), "")
+
+	out, _ = dockerCmd(c, "exec", "top", "sh", "-c", fmt.Sprintf("cat %s && cat %s", file1, file2))
+	c.Assert(strings.TrimSpace(out), checker.Equals, "50000\n100000")
+
+	out = inspectField(c, "top", "HostConfig.NanoCpus")
+	c.Assert(out, checker.Equals, "500000000", check.Commentf("setting the Nano CPUs failed"))
+
+	dockerCmd(c, "update", "--cpus", "0.8", "top")
+	out = inspectField(c, "top", "HostConfig.NanoCpus")
+	c.Assert(out, checker.Equals, "800000000", check.Commentf("updating the Nano CPUs failed"))
+
+	out, _ = dockerCmd(c, "exec", "top", "sh", "-c", fmt.Sprintf("cat %s && cat %s", file1, file2))
+	c.Assert(strings.TrimSpace(out), checker.Equals, "80000\n100000")
+
+	out, _, err := dockerCmdWithError("update", "--cpu-quota", "80000", "top")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "Conflicting options: CPU Quota or CPU Period cannot be updated as NanoCPUs has already been set")
+}
+
+func (s *DockerSuite) TestUpdateNetworkRate(c *check.C) {
+	testRequires(c, DaemonIsLinux, SameHostDaemon, NotUserNamespace)
+
+	dockerCmd(c, "run", "-d", "--network-rate", "ingress=1mb", "--name", "top", "busybox", "top")
+	c.Assert(inspectField(c, "top", "HostConfig.NetworkIngressRate"), checker.Equals, "1048576")
+
+	// The traffic received by the container is shaped on the host side of
+	// its veth pair.
+	out, _, err := runCommandWithOutput(exec.Command("tc", "qdisc", "show"))
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+	c.Assert(out, checker.Contains, "rate 8388Kbit")
+
+	dockerCmd(c, "update", "--network-rate", "ingress=2mb", "top")
+	c.Assert(inspectField(c, "top", "HostConfig.NetworkIngressRate"), checker.Equals, "2097152")
+
+	out, _, err = runCommandWithOutput(exec.Command("tc", "qdisc", "show"))
+	c.Assert(err, checker.IsNil, check.Commentf(out))
+	c.Assert(out, checker.Contains, "rate 16777Kbit")
+
+	out, _, err = dockerCmdWithError("run", "--net=host", "--network-rate", "egress=1mb", "busybox", "true")
+	c.Assert(err, checker.NotNil)
+	c.Assert(out, checker.Contains, "Conflicting options: network rate and the network mode")
+}
@@ -79,6 +79,17 @@ func (s *DockerSuite) TestVolumeCliLs(c *check.C) {
 	assertVolList(c, out, []string{"aaa", "soo", "test"})
 }
 
+func (s *DockerSuite) TestVolumeCliLsFormat(c *check.C) {
+	dockerCmd(c, "volume", "create", "--name", "aaa")
+	dockerCmd(c, "volume", "create", "--name", "test")
+
+	out, _ := dockerCmd(c, "volume", "ls", "--format", "{{.Name}}: {{.Driver}}")
+	c.Assert(strings.Split(strings.TrimSpace(out), "\n"), checker.DeepEquals, []string{"aaa: local", "test: local"})
+
+	out, _ = dockerCmd(c, "volume", "ls", "--format", "table {{.Name}}")
+	c.Assert(strings.Split(strings.TrimSpace(out), "\n"), checker.DeepEquals, []string{"VOLUME NAME", "aaa", "test"})
+}
+
 // assertVolList checks volume retrieved with ls command
 // equals to expected volume list
 // note: out should be `volume ls [option]` result
@@ -15,6 +15,7 @@ import (
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/distribution/digest"
+	"github.com/docker/docker/daemon/graphdriver"
 	"github.com/docker/docker/pkg/archive"
 )
 
@@ -53,6 +54,11 @@ var (
 	// ErrNotSupported is used when the action is not supppoted
 	// on the current platform
 	ErrNotSupported = errors.New("not support on this platform")
+
+	// ErrSnapshotNotSupported is used when a snapshot of a
+	// mount is requested but the driver does not support
+	// snapshots.
+	ErrSnapshotNotSupported = errors.New("snapshots not supported by the storage driver")
 )
 
 // ChainID is the content-addressable ID of a layer.
@@ -137,6 +143,17 @@ type RWLayer interface {
 	// from the base layer.
 	Changes() ([]archive.Change, error)
 
+	// SnapshotTarStream returns a tar archive of the changes of the
+	// mutable layer, taken from a snapshot of the layer so that it is
+	// consistent while the layer is in use. ErrSnapshotNotSupported
+	// is returned if the driver does not support snapshots.
+	SnapshotTarStream() (io.ReadCloser, error)
+
+	// DiffGetter returns a FileGetCloser to read the contents of the
+	// files of the mutable layer, by their path in the layer. It must
+	// be closed once done.
+	DiffGetter() (graphdriver.FileGetCloser, error)
+
 	// Metadata returns the low level metadata for the mutable layer
 	Metadata() (map[string]string, error)
 }
@@ -1,13 +1,17 @@
 package layer
 
 import (
+	"archive/tar"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"sort"
 	"testing"
 
+	"github.com/docker/docker/daemon/graphdriver"
 	"github.com/docker/docker/pkg/archive"
 )
 
@@ -197,6 +201,126 @@ func TestMountChanges(t *testing.T) {
 	})
 }
 
+func TestMountDiffGetter(t *testing.T) {
+	// TODO Windows: Figure out why this is failing
+	if runtime.GOOS == "windows" {
+		t.Skip("Failing on Windows")
+	}
+	ls, _, cleanup := newTestStore(t)
+	defer cleanup()
+
+	li := initWithFiles(newTestFile("testfile.txt", []byte("base data!"), 0644))
+	layer, err := createLayer(ls, "", li)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := ls.CreateRWLayer("mount-diff-getter", layer.ChainID(), "", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := m.Mount("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Unmount()
+
+	if err := ioutil.WriteFile(filepath.Join(path, "testfile.txt"), []byte("mount data!"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fg, err := m.DiffGetter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fg.Close()
+
+	f, err := fg.Get("/testfile.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	b, err := ioutil.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "mount data!"; string(b) != expected {
+		t.Fatalf("Unexpected test file contents %q, expected %q", string(b), expected)
+	}
+}
+
+// snapshotDriver adds snapshots to a driver creating the layers as copies of
+// their parent.
+type snapshotDriver struct {
+	graphdriver.Driver
+}
+
+func (d *snapshotDriver) Snapshot(id, parent string) error {
+	return d.Create(id, parent, "", nil)
+}
+
+func TestMountSnapshotTarStream(t *testing.T) {
+	// TODO Windows: Figure out why this is failing
+	if runtime.GOOS == "windows" {
+		t.Skip("Failing on Windows")
+	}
+	ls, _, cleanup := newTestStore(t)
+	defer cleanup()
+
+	li := initWithFiles(newTestFile("testfile.txt", []byte("base data!"), 0644))
+	layer, err := createLayer(ls, "", li)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := ls.CreateRWLayer("mount-snapshot", layer.ChainID(), "", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := m.Mount("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Unmount()
+
+	if err := ioutil.WriteFile(filepath.Join(path, "newfile.txt"), []byte("mount data!"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.SnapshotTarStream(); err != ErrSnapshotNotSupported {
+		t.Fatalf("Expected ErrSnapshotNotSupported, got %v", err)
+	}
+
+	ls.(*layerStore).driver = &snapshotDriver{ls.(*layerStore).driver}
+
+	ts, err := m.SnapshotTarStream()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	tr := tar.NewReader(ts)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, hdr.Name)
+	}
+	if err := ts.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{"newfile.txt"}; !reflect.DeepEqual(names, expected) {
+		t.Fatalf("Unexpected snapshot entries %v, expected %v", names, expected)
+	}
+}
+
 func assertChange(t *testing.T, actual, expected archive.Change) {
 	if actual.Path != expected.Path {
 		t.Fatalf("Unexpected change path %s, expected %s", actual.Path, expected.Path)
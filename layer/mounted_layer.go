@@ -4,7 +4,10 @@ import (
 	"io"
 	"sync"
 
+	"github.com/docker/docker/daemon/graphdriver"
 	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/docker/docker/pkg/stringid"
 )
 
 type mountedLayer struct {
@@ -36,6 +39,32 @@ func (ml *mountedLayer) TarStream() (io.ReadCloser, error) {
 	return archiver, nil
 }
 
+func (ml *mountedLayer) SnapshotTarStream() (io.ReadCloser, error) {
+	driver := ml.layerStore.driver
+	snapshotter, ok := graphdriver.GetSnapshotter(driver)
+	if !ok {
+		return nil, ErrSnapshotNotSupported
+	}
+
+	// The snapshot is removed once the archive is read.
+	snapshotID := ml.mountID + "-snapshot-" + stringid.GenerateRandomID()[:12]
+	if err := snapshotter.Snapshot(snapshotID, ml.mountID); err != nil {
+		return nil, err
+	}
+	archiver, err := driver.Diff(snapshotID, ml.cacheParent())
+	if err != nil {
+		driver.Remove(snapshotID)
+		return nil, err
+	}
+	return ioutils.NewReadCloserWrapper(archiver, func() error {
+		err := archiver.Close()
+		if rmErr := driver.Remove(snapshotID); err == nil {
+			err = rmErr
+		}
+		return err
+	}), nil
+}
+
 func (ml *mountedLayer) Name() string {
 	return ml.name
 }
@@ -66,6 +95,14 @@ func (ml *mountedLayer) Changes() ([]archive.Change, error) {
 	return ml.layerStore.driver.Changes(ml.mountID, ml.cacheParent())
 }
 
+func (ml *mountedLayer) DiffGetter() (graphdriver.FileGetCloser, error) {
+	diffDriver, ok := ml.layerStore.driver.(graphdriver.DiffGetterDriver)
+	if !ok {
+		diffDriver = &naiveDiffPathDriver{ml.layerStore.driver}
+	}
+	return diffDriver.DiffGetter(ml.mountID)
+}
+
 func (ml *mountedLayer) Metadata() (map[string]string, error) {
 	return ml.layerStore.driver.GetMetadata(ml.mountID)
 }
@@ -2,6 +2,7 @@ package libcontainerd
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -19,7 +20,29 @@ type container struct {
 
 	// Platform specific fields are below here.
 	pauseMonitor
-	oom bool
+	oom         bool
+	runtime     string
+	runtimeArgs []string
+}
+
+// WithRuntime sets the OCI runtime binary, and its arguments, used by
+// containerd to run the container instead of its default runtime.
+func WithRuntime(path string, args []string) CreateOption {
+	return runtime{path, args}
+}
+
+type runtime struct {
+	path string
+	args []string
+}
+
+func (rt runtime) Apply(p interface{}) error {
+	if pr, ok := p.(*container); ok {
+		pr.runtime = rt.path
+		pr.runtimeArgs = rt.args
+		return nil
+	}
+	return fmt.Errorf("WithRuntime option not supported for this client")
 }
 
 func (ctr *container) clean() error {
@@ -80,6 +103,8 @@ func (ctr *container) start() error {
 		Stderr:     ctr.fifo(syscall.Stderr),
 		// check to see if we are running in ramdisk to disable pivot root
 		NoPivotRoot: os.Getenv("DOCKER_RAMDISK") != "",
+		Runtime:     ctr.runtime,
+		RuntimeArgs: ctr.runtimeArgs,
 	}
 	ctr.client.appendContainer(ctr)
 
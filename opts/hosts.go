@@ -29,8 +29,9 @@ var (
 // ValidateHost validates that the specified string is a valid host and returns it.
 func ValidateHost(val string) (string, error) {
 	host := strings.TrimSpace(val)
-	// The empty string means default and is not handled by parseDockerDaemonHost
-	if host != "" {
+	// The empty string means default and is not handled by parseDockerDaemonHost.
+	// ssh hosts are only supported by the client, which validates them itself.
+	if host != "" && !strings.HasPrefix(host, "ssh://") {
 		_, err := parseDockerDaemonHost(host)
 		if err != nil {
 			return val, err
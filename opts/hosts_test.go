@@ -14,6 +14,7 @@ func TestParseHost(t *testing.T) {
 		"tcp://:port",
 		"tcp://invalid",
 		"tcp://invalid:port",
+		"ssh://example.com",
 	}
 
 	valid := map[string]string{
@@ -230,3 +230,50 @@ func TestNamedMapOpts(t *testing.T) {
 		t.Errorf("expected map-size to be in the values, got %v", tmpMap)
 	}
 }
+
+func TestParseCPUs(t *testing.T) {
+	valid := map[string]int64{
+		"1":     1e9,
+		"1.5":   15e8,
+		"0.01":  1e7,
+		"0.001": 1e6,
+		"3/2":   15e8,
+	}
+	for value, expected := range valid {
+		cpus, err := ParseCPUs(value)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", value, err)
+			continue
+		}
+		if cpus != expected {
+			t.Errorf("expected %d for %s, got %d", expected, value, cpus)
+		}
+	}
+
+	invalid := map[string]string{
+		"invalid":      "failed to parse invalid as a rational number",
+		"2/3":          "value is too precise",
+		"0.0000000001": "value is too precise",
+	}
+	for value, expected := range invalid {
+		if _, err := ParseCPUs(value); err == nil || err.Error() != expected {
+			t.Errorf("expected error %q for %s, got %v", expected, value, err)
+		}
+	}
+}
+
+func TestNanoCPUs(t *testing.T) {
+	var c NanoCPUs
+	if c.String() != "" {
+		t.Errorf("expected an empty string, got %s", c.String())
+	}
+	if err := c.Set("1.5"); err != nil {
+		t.Fatal(err)
+	}
+	if c.Value() != 15e8 {
+		t.Errorf("expected 1500000000, got %d", c.Value())
+	}
+	if c.String() != "1.500" {
+		t.Errorf("expected 1.500, got %s", c.String())
+	}
+}
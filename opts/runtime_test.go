@@ -0,0 +1,35 @@
+package opts
+
+import (
+	"testing"
+
+	"github.com/docker/engine-api/types"
+)
+
+func TestRuntimeOptSet(t *testing.T) {
+	runtimes := make(map[string]types.Runtime)
+	o := NewNamedRuntimeOpt("runtimes", &runtimes, "runc")
+
+	if err := o.Set("Sandbox=/usr/local/bin/sandbox-runc"); err != nil {
+		t.Fatal(err)
+	}
+	if rt, ok := runtimes["sandbox"]; !ok || rt.Path != "/usr/local/bin/sandbox-runc" {
+		t.Fatalf("Expected runtime sandbox to be set, got %v", runtimes)
+	}
+	if o.String() != "[sandbox]" {
+		t.Fatalf("Expected [sandbox], got %s", o.String())
+	}
+
+	invalids := []string{
+		"sandbox",
+		"sandbox=",
+		"=/usr/local/bin/runc",
+		"runc=/usr/local/bin/runc",
+		"sandbox=/usr/local/bin/other",
+	}
+	for _, value := range invalids {
+		if err := o.Set(value); err == nil {
+			t.Fatalf("Expected an error setting %q", value)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/pkg/fileutils"
 	"github.com/docker/docker/pkg/idtools"
 	"github.com/docker/docker/pkg/pools"
 	"github.com/docker/docker/pkg/system"
@@ -277,3 +278,78 @@ func applyLayerHandler(dest string, layer Reader, options *TarOptions, decompres
 	}
 	return UnpackLayer(dest, layer, options)
 }
+
+// ExcludeLayerEntries returns the layer without the entries matching the
+// exclusion patterns, which are in the .dockerignore format and relative to
+// the root of the layer. Whiteouts are matched with the path they remove, and
+// hard links are excluded with their target, which is not in the layer
+// anymore.
+func ExcludeLayerEntries(layer Reader, excludes []string) (Archive, error) {
+	patterns, patDirs, _, err := fileutils.CleanPatterns(excludes)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered, w := io.Pipe()
+
+	go func() {
+		srcTar := tar.NewReader(layer)
+		filteredTar := tar.NewWriter(w)
+		excludedFiles := make(map[string]bool)
+
+		for {
+			hdr, err := srcTar.Next()
+			if err == io.EOF {
+				// Signals end of archive.
+				filteredTar.Close()
+				w.Close()
+				return
+			}
+			if err != nil {
+				w.CloseWithError(err)
+				return
+			}
+
+			name := filepath.Clean(hdr.Name)
+			excluded, err := fileutils.OptimizedMatches(whiteoutTarget(name), patterns, patDirs)
+			if err != nil {
+				w.CloseWithError(err)
+				return
+			}
+			if hdr.Typeflag == tar.TypeLink && excludedFiles[filepath.Clean(hdr.Linkname)] {
+				excluded = true
+			}
+			if excluded {
+				excludedFiles[name] = true
+				continue
+			}
+
+			if err = filteredTar.WriteHeader(hdr); err != nil {
+				w.CloseWithError(err)
+				return
+			}
+			if _, err = io.Copy(filteredTar, srcTar); err != nil {
+				w.CloseWithError(err)
+				return
+			}
+		}
+	}()
+
+	return filtered, nil
+}
+
+// whiteoutTarget returns the path removed by the whiteout at the path, or
+// the path itself if it is not a whiteout. The opaque whiteout of a
+// directory removes the directory.
+func whiteoutTarget(path string) string {
+	dir, base := filepath.Split(path)
+	switch {
+	case base == WhiteoutOpaqueDir:
+		return filepath.Clean(dir)
+	case strings.HasPrefix(base, WhiteoutMetaPrefix):
+		return path
+	case strings.HasPrefix(base, WhiteoutPrefix):
+		return filepath.Join(dir, strings.TrimPrefix(base, WhiteoutPrefix))
+	}
+	return path
+}
@@ -2,6 +2,7 @@ package archive
 
 import (
 	"archive/tar"
+	"bytes"
 	"io"
 	"io/ioutil"
 	"os"
@@ -384,3 +385,51 @@ func readDirContents(root string) ([]string, error) {
 	}
 	return files, nil
 }
+
+func TestExcludeLayerEntries(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, hdr := range []*tar.Header{
+		{Name: "etc/", Typeflag: tar.TypeDir, Mode: 0755},
+		{Name: "etc/app.conf", Typeflag: tar.TypeReg, Mode: 0644},
+		{Name: "etc/.wh.old.conf", Typeflag: tar.TypeReg, Mode: 0644},
+		{Name: "tmp/", Typeflag: tar.TypeDir, Mode: 0755},
+		{Name: "tmp/cache/", Typeflag: tar.TypeDir, Mode: 0755},
+		{Name: "tmp/cache/.wh..wh..opq", Typeflag: tar.TypeReg, Mode: 0644},
+		{Name: "tmp/cache/data", Typeflag: tar.TypeReg, Mode: 0644},
+		{Name: "tmp/data.sock", Typeflag: tar.TypeReg, Mode: 0644},
+		{Name: "tmp/keep.sock", Typeflag: tar.TypeReg, Mode: 0644},
+		{Name: "tmp/link", Typeflag: tar.TypeLink, Linkname: "tmp/cache/data"},
+	} {
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	filtered, err := ExcludeLayerEntries(&buf, []string{"etc/old.conf", "tmp/cache", "tmp/*.sock", "!tmp/keep.sock"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer filtered.Close()
+
+	var names []string
+	tr := tar.NewReader(filtered)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, hdr.Name)
+	}
+
+	expected := []string{"etc/", "etc/app.conf", "tmp/", "tmp/keep.sock"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected entries %v, got %v", expected, names)
+	}
+}
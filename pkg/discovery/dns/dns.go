@@ -0,0 +1,122 @@
+package dns
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/pkg/discovery"
+)
+
+// lookupSRV is the resolver used to fetch the SRV records, it can be
+// replaced in tests.
+var lookupSRV = net.LookupSRV
+
+// Discovery is exported
+type Discovery struct {
+	heartbeat time.Duration
+	name      string
+}
+
+func init() {
+	Init()
+}
+
+// Init is exported
+func Init() {
+	discovery.Register("dns", &Discovery{})
+}
+
+// Initialize is exported
+func (s *Discovery) Initialize(name string, heartbeat time.Duration, ttl time.Duration, _ map[string]string) error {
+	name = strings.TrimSuffix(name, "/")
+	if name == "" {
+		return fmt.Errorf("missing SRV record name, please use dns://_service._proto.domain")
+	}
+	s.name = name
+	s.heartbeat = heartbeat
+	return nil
+}
+
+func (s *Discovery) fetch() (discovery.Entries, error) {
+	_, records, err := lookupSRV("", "", s.name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup SRV records of '%s': %v", s.name, err)
+	}
+	return createEntries(records), nil
+}
+
+// createEntries converts SRV records into entries, sorted so that a change
+// in the order of the answers is not reported as a change of the cluster.
+func createEntries(records []*net.SRV) discovery.Entries {
+	entries := discovery.Entries{}
+	for _, record := range records {
+		entry := &discovery.Entry{
+			Host: strings.TrimSuffix(record.Target, "."),
+			Port: strconv.Itoa(int(record.Port)),
+		}
+		if !entries.Contains(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Sort(byAddress(entries))
+	return entries
+}
+
+type byAddress discovery.Entries
+
+func (e byAddress) Len() int           { return len(e) }
+func (e byAddress) Swap(i, j int)      { e[i], e[j] = e[j], e[i] }
+func (e byAddress) Less(i, j int) bool { return e[i].String() < e[j].String() }
+
+// Watch is exported
+func (s *Discovery) Watch(stopCh <-chan struct{}) (<-chan discovery.Entries, <-chan error) {
+	ch := make(chan discovery.Entries)
+	errCh := make(chan error)
+	ticker := time.NewTicker(s.heartbeat)
+
+	go func() {
+		defer close(errCh)
+		defer close(ch)
+
+		// Send the initial entries if available.
+		currentEntries, err := s.fetch()
+		if err != nil {
+			errCh <- err
+		} else {
+			ch <- currentEntries
+		}
+
+		// Periodically send updates.
+		for {
+			select {
+			case <-ticker.C:
+				newEntries, err := s.fetch()
+				if err != nil {
+					errCh <- err
+					continue
+				}
+
+				// Check if the records have really changed.
+				if !newEntries.Equals(currentEntries) {
+					ch <- newEntries
+				}
+				currentEntries = newEntries
+			case <-stopCh:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+
+	return ch, errCh
+}
+
+// Register is exported. DNS records are managed outside of the daemon, so
+// nodes cannot register themselves.
+func (s *Discovery) Register(addr string) error {
+	return discovery.ErrNotImplemented
+}
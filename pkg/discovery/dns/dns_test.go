@@ -0,0 +1,105 @@
+package dns
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/docker/docker/pkg/discovery"
+
+	"github.com/go-check/check"
+)
+
+// Hook up gocheck into the "go test" runner.
+func Test(t *testing.T) { check.TestingT(t) }
+
+type DiscoverySuite struct{}
+
+var _ = check.Suite(&DiscoverySuite{})
+
+func (s *DiscoverySuite) TestInitialize(c *check.C) {
+	d := &Discovery{}
+	c.Assert(d.Initialize("_docker._tcp.example.com", 1000, 0, nil), check.IsNil)
+	c.Assert(d.name, check.Equals, "_docker._tcp.example.com")
+
+	c.Assert(d.Initialize("", 1000, 0, nil), check.NotNil)
+}
+
+func (s *DiscoverySuite) TestNew(c *check.C) {
+	d, err := discovery.New("dns://_docker._tcp.example.com", 0, 0, nil)
+	c.Assert(err, check.IsNil)
+	c.Assert(d.(*Discovery).name, check.Equals, "_docker._tcp.example.com")
+}
+
+func (s *DiscoverySuite) TestCreateEntries(c *check.C) {
+	records := []*net.SRV{
+		{Target: "node2.example.com.", Port: 2376},
+		{Target: "node1.example.com.", Port: 2376},
+		{Target: "node2.example.com.", Port: 2376},
+	}
+	c.Assert(createEntries(records), check.DeepEquals, discovery.Entries{
+		&discovery.Entry{Host: "node1.example.com", Port: "2376"},
+		&discovery.Entry{Host: "node2.example.com", Port: "2376"},
+	})
+}
+
+func (s *DiscoverySuite) TestRegister(c *check.C) {
+	d := &Discovery{name: "_docker._tcp.example.com"}
+	c.Assert(d.Register("0.0.0.0"), check.NotNil)
+}
+
+func (s *DiscoverySuite) TestWatch(c *check.C) {
+	var (
+		mu      sync.Mutex
+		records []*net.SRV
+	)
+	lookupSRV = func(service, proto, name string) (string, []*net.SRV, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if records == nil {
+			return "", nil, errors.New("no such host")
+		}
+		return name, records, nil
+	}
+	defer func() { lookupSRV = net.LookupSRV }()
+
+	expected := discovery.Entries{
+		&discovery.Entry{Host: "1.1.1.1", Port: "1111"},
+		&discovery.Entry{Host: "2.2.2.2", Port: "2222"},
+	}
+
+	d := &Discovery{}
+	d.Initialize("_docker._tcp.example.com", 1000, 0, nil)
+	stopCh := make(chan struct{})
+	ch, errCh := d.Watch(stopCh)
+
+	// Make sure it fires errors since the record doesn't exist.
+	c.Assert(<-errCh, check.NotNil)
+	// We have to drain the error channel otherwise Watch will get stuck.
+	go func() {
+		for range errCh {
+		}
+	}()
+
+	// Create the records and make sure we get the expected value back.
+	mu.Lock()
+	records = []*net.SRV{
+		{Target: "2.2.2.2", Port: 2222},
+		{Target: "1.1.1.1", Port: 1111},
+	}
+	mu.Unlock()
+	c.Assert(<-ch, check.DeepEquals, expected)
+
+	// Add a new record and look it up.
+	expected = append(expected, &discovery.Entry{Host: "3.3.3.3", Port: "3333"})
+	mu.Lock()
+	records = append(records, &net.SRV{Target: "3.3.3.3", Port: 3333})
+	mu.Unlock()
+	c.Assert(<-ch, check.DeepEquals, expected)
+
+	// Stop and make sure it closes all channels.
+	close(stopCh)
+	c.Assert(<-ch, check.IsNil)
+	c.Assert(<-errCh, check.IsNil)
+}
@@ -0,0 +1,172 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/pkg/discovery"
+)
+
+const (
+	// requestTimeout is the timeout of the requests to the service.
+	requestTimeout = 10 * time.Second
+)
+
+// Discovery is exported
+type Discovery struct {
+	scheme    string
+	url       string
+	heartbeat time.Duration
+	ttl       time.Duration
+	client    *http.Client
+}
+
+func init() {
+	Init()
+}
+
+// Init is exported
+func Init() {
+	discovery.Register("http", &Discovery{scheme: "http"})
+	discovery.Register("https", &Discovery{scheme: "https"})
+}
+
+// Initialize is exported
+func (s *Discovery) Initialize(uri string, heartbeat time.Duration, ttl time.Duration, _ map[string]string) error {
+	s.url = s.scheme + "://" + uri
+	if _, err := url.Parse(s.url); err != nil {
+		return err
+	}
+	s.heartbeat = heartbeat
+	s.ttl = ttl
+	s.client = &http.Client{Timeout: requestTimeout}
+	return nil
+}
+
+// parseContent reads the list of nodes returned by the service. It is either
+// a JSON array of addresses, or one address per line, in the format of the
+// file discovery.
+func parseContent(contentType string, content []byte) ([]string, error) {
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType == "application/json" {
+		var addrs []string
+		if err := json.Unmarshal(content, &addrs); err != nil {
+			return nil, err
+		}
+		return addrs, nil
+	}
+
+	var result []string
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		// Comments and empty lines are ignored.
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		result = append(result, discovery.Generate(line)...)
+	}
+	return result, nil
+}
+
+func (s *Discovery) fetch() (discovery.Entries, error) {
+	resp, err := s.client.Get(s.url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch '%s': %s", s.url, resp.Status)
+	}
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read '%s': %v", s.url, err)
+	}
+	addrs, err := parseContent(resp.Header.Get("Content-Type"), content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse '%s': %v", s.url, err)
+	}
+	return discovery.CreateEntries(addrs)
+}
+
+// Watch is exported
+func (s *Discovery) Watch(stopCh <-chan struct{}) (<-chan discovery.Entries, <-chan error) {
+	ch := make(chan discovery.Entries)
+	errCh := make(chan error)
+	ticker := time.NewTicker(s.heartbeat)
+
+	go func() {
+		defer close(errCh)
+		defer close(ch)
+
+		// Send the initial entries if available.
+		currentEntries, err := s.fetch()
+		if err != nil {
+			errCh <- err
+		} else {
+			ch <- currentEntries
+		}
+
+		// Periodically send updates.
+		for {
+			select {
+			case <-ticker.C:
+				newEntries, err := s.fetch()
+				if err != nil {
+					errCh <- err
+					continue
+				}
+
+				// Check if the list has really changed.
+				if !newEntries.Equals(currentEntries) {
+					ch <- newEntries
+				}
+				currentEntries = newEntries
+			case <-stopCh:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+
+	return ch, errCh
+}
+
+// Register is exported. The address is POSTed to the service along with the
+// ttl in seconds, the service is expected to drop it if it is not registered
+// again before the ttl expires. Services which do not accept registrations
+// reply with 405 Method Not Allowed or 501 Not Implemented.
+func (s *Discovery) Register(addr string) error {
+	u, err := url.Parse(s.url)
+	if err != nil {
+		return err
+	}
+	if s.ttl > 0 {
+		q := u.Query()
+		q.Set("ttl", strconv.Itoa(int(s.ttl.Seconds())))
+		u.RawQuery = q.Encode()
+	}
+
+	resp, err := s.client.Post(u.String(), "text/plain", strings.NewReader(addr))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusMethodNotAllowed, resp.StatusCode == http.StatusNotImplemented:
+		return discovery.ErrNotImplemented
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return fmt.Errorf("failed to register '%s' to '%s': %s", addr, s.url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package http
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/docker/docker/pkg/discovery"
+
+	"github.com/go-check/check"
+)
+
+// Hook up gocheck into the "go test" runner.
+func Test(t *testing.T) { check.TestingT(t) }
+
+type DiscoverySuite struct{}
+
+var _ = check.Suite(&DiscoverySuite{})
+
+func (s *DiscoverySuite) TestInitialize(c *check.C) {
+	d := &Discovery{scheme: "http"}
+	c.Assert(d.Initialize("inventory.example.com/docker/nodes", 1000, 0, nil), check.IsNil)
+	c.Assert(d.url, check.Equals, "http://inventory.example.com/docker/nodes")
+}
+
+func (s *DiscoverySuite) TestNew(c *check.C) {
+	d, err := discovery.New("https://inventory.example.com/docker/nodes", 0, 0, nil)
+	c.Assert(err, check.IsNil)
+	c.Assert(d.(*Discovery).url, check.Equals, "https://inventory.example.com/docker/nodes")
+}
+
+func (s *DiscoverySuite) TestContent(c *check.C) {
+	data := `
+# docker nodes
+1.1.1.[1:2]:1111 # inline comment
+2.2.2.2:2222
+`
+	ips, err := parseContent("text/plain", []byte(data))
+	c.Assert(err, check.IsNil)
+	c.Assert(ips, check.DeepEquals, []string{"1.1.1.1:1111", "1.1.1.2:1111", "2.2.2.2:2222"})
+
+	ips, err = parseContent("application/json; charset=utf-8", []byte(`["1.1.1.1:1111","2.2.2.2:2222"]`))
+	c.Assert(err, check.IsNil)
+	c.Assert(ips, check.DeepEquals, []string{"1.1.1.1:1111", "2.2.2.2:2222"})
+
+	_, err = parseContent("application/json", []byte(`{"nodes":[]}`))
+	c.Assert(err, check.NotNil)
+}
+
+func (s *DiscoverySuite) TestRegister(c *check.C) {
+	var registered, ttl string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		registered = string(body)
+		ttl = r.URL.Query().Get("ttl")
+	}))
+	defer server.Close()
+
+	d := &Discovery{scheme: "http"}
+	d.Initialize(strings.TrimPrefix(server.URL, "http://"), 1000, 60e9, nil)
+	c.Assert(d.Register("1.1.1.1:1111"), check.IsNil)
+	c.Assert(registered, check.Equals, "1.1.1.1:1111")
+	c.Assert(ttl, check.Equals, "60")
+
+	readonly := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}))
+	defer readonly.Close()
+
+	d.Initialize(strings.TrimPrefix(readonly.URL, "http://"), 1000, 0, nil)
+	c.Assert(d.Register("1.1.1.1:1111"), check.Equals, discovery.ErrNotImplemented)
+}
+
+func (s *DiscoverySuite) TestWatch(c *check.C) {
+	var (
+		mu   sync.Mutex
+		data string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if data == "" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(data))
+	}))
+	defer server.Close()
+
+	expected := discovery.Entries{
+		&discovery.Entry{Host: "1.1.1.1", Port: "1111"},
+		&discovery.Entry{Host: "2.2.2.2", Port: "2222"},
+	}
+
+	d := &Discovery{scheme: "http"}
+	d.Initialize(strings.TrimPrefix(server.URL, "http://"), 1000, 0, nil)
+	stopCh := make(chan struct{})
+	ch, errCh := d.Watch(stopCh)
+
+	// Make sure it fires errors since the list doesn't exist.
+	c.Assert(<-errCh, check.NotNil)
+	// We have to drain the error channel otherwise Watch will get stuck.
+	go func() {
+		for range errCh {
+		}
+	}()
+
+	// Serve the list and make sure we get the expected value back.
+	mu.Lock()
+	data = "1.1.1.1:1111\n2.2.2.2:2222\n"
+	mu.Unlock()
+	c.Assert(<-ch, check.DeepEquals, expected)
+
+	// Add a new entry and look it up.
+	expected = append(expected, &discovery.Entry{Host: "3.3.3.3", Port: "3333"})
+	mu.Lock()
+	data += "3.3.3.3:3333\n"
+	mu.Unlock()
+	c.Assert(<-ch, check.DeepEquals, expected)
+
+	// Stop and make sure it closes all channels.
+	close(stopCh)
+	c.Assert(<-ch, check.IsNil)
+	c.Assert(<-errCh, check.IsNil)
+}
@@ -147,6 +147,44 @@ func createIDMap(subidRanges ranges) []IDMap {
 	return idMap
 }
 
+// SplitIDMap splits the host IDs covered by an id mapping into consecutive
+// blocks of size IDs. Each block is returned as an id mapping of its own,
+// translating container IDs 0 to size-1 to the host IDs of the block. Host
+// IDs left over after the last complete block are not returned.
+func SplitIDMap(idMap []IDMap, size int) [][]IDMap {
+	if size <= 0 {
+		return nil
+	}
+
+	var (
+		blocks  [][]IDMap
+		current []IDMap
+		used    int
+	)
+	for _, m := range idMap {
+		hostID, length := m.HostID, m.Size
+		for length > 0 {
+			n := size - used
+			if length < n {
+				n = length
+			}
+			current = append(current, IDMap{
+				ContainerID: used,
+				HostID:      hostID,
+				Size:        n,
+			})
+			used += n
+			hostID += n
+			length -= n
+			if used == size {
+				blocks = append(blocks, current)
+				current, used = nil, 0
+			}
+		}
+	}
+	return blocks
+}
+
 func parseSubuid(username string) (ranges, error) {
 	return parseSubidFile(subuidFileName, username)
 }
@@ -0,0 +1,39 @@
+package idtools
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitIDMap(t *testing.T) {
+	idMap := []IDMap{
+		{ContainerID: 0, HostID: 100000, Size: 65536},
+		{ContainerID: 65536, HostID: 300000, Size: 100000},
+	}
+
+	blocks := SplitIDMap(idMap, 65536)
+	expected := [][]IDMap{
+		{{ContainerID: 0, HostID: 100000, Size: 65536}},
+		{{ContainerID: 0, HostID: 300000, Size: 65536}},
+	}
+	if !reflect.DeepEqual(blocks, expected) {
+		t.Fatalf("wanted %v, got %v", expected, blocks)
+	}
+
+	blocks = SplitIDMap(idMap, 50000)
+	expected = [][]IDMap{
+		{{ContainerID: 0, HostID: 100000, Size: 50000}},
+		{
+			{ContainerID: 0, HostID: 150000, Size: 15536},
+			{ContainerID: 15536, HostID: 300000, Size: 34464},
+		},
+		{{ContainerID: 0, HostID: 334464, Size: 50000}},
+	}
+	if !reflect.DeepEqual(blocks, expected) {
+		t.Fatalf("wanted %v, got %v", expected, blocks)
+	}
+
+	if blocks := SplitIDMap(idMap, 0); blocks != nil {
+		t.Fatalf("wanted no blocks, got %v", blocks)
+	}
+}
@@ -0,0 +1,140 @@
+package opts
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	mounttypes "github.com/docker/engine-api/types/mount"
+)
+
+func TestMountOptDefaultType(t *testing.T) {
+	var mount MountOpt
+	if err := mount.Set("target=/target,source=/foo"); err != nil {
+		t.Fatal(err)
+	}
+	if mount.values[0].Type != mounttypes.TypeVolume {
+		t.Fatalf("expected default type %q, got %q", mounttypes.TypeVolume, mount.values[0].Type)
+	}
+}
+
+func TestMountOptSetNoError(t *testing.T) {
+	for _, testcase := range []string{
+		// tests several aliases that should have same result.
+		"type=bind,target=/target,source=/source",
+		"type=bind,src=/source,dst=/target",
+		"type=bind,source=/source,dst=/target",
+		"type=bind,src=/source,target=/target",
+	} {
+		var mount MountOpt
+		if err := mount.Set(testcase); err != nil {
+			t.Fatalf("unexpected error for %s: %v", testcase, err)
+		}
+
+		mounts := mount.Value()
+		if len(mounts) != 1 {
+			t.Fatalf("expected 1 mount for %s, got %d", testcase, len(mounts))
+		}
+		expected := mounttypes.Mount{
+			Type:   mounttypes.TypeBind,
+			Source: "/source",
+			Target: "/target",
+		}
+		if mounts[0] != expected {
+			t.Fatalf("expected %+v for %s, got %+v", expected, testcase, mounts[0])
+		}
+	}
+}
+
+func TestMountOptSourceWithColons(t *testing.T) {
+	var mount MountOpt
+	if err := mount.Set(`type=bind,"source=/with:colons,and,commas",target=/target:colon`); err != nil {
+		t.Fatal(err)
+	}
+	m := mount.Value()[0]
+	if m.Source != "/with:colons,and,commas" || m.Target != "/target:colon" {
+		t.Fatalf("unexpected mount %+v", m)
+	}
+}
+
+func TestMountOptReadOnly(t *testing.T) {
+	for value, expected := range map[string]bool{
+		"type=bind,target=/target,source=/foo":               false,
+		"type=bind,target=/target,source=/foo,readonly":      true,
+		"type=bind,target=/target,source=/foo,ro":            true,
+		"type=bind,target=/target,source=/foo,readonly=true": true,
+		"type=bind,target=/target,source=/foo,readonly=0":    false,
+		"type=bind,target=/target,source=/foo,ro=false":      false,
+	} {
+		var mount MountOpt
+		if err := mount.Set(value); err != nil {
+			t.Fatalf("unexpected error for %s: %v", value, err)
+		}
+		if mount.values[0].ReadOnly != expected {
+			t.Fatalf("expected readonly %v for %s", expected, value)
+		}
+	}
+}
+
+func TestMountOptVolumeOptions(t *testing.T) {
+	var mount MountOpt
+	if err := mount.Set("type=volume,source=foo,target=/target,volume-driver=mydriver,volume-opt=size=10G,volume-opt=fast,volume-label=a=b,volume-nocopy"); err != nil {
+		t.Fatal(err)
+	}
+	opts := mount.Value()[0].VolumeOptions
+	if opts == nil || opts.DriverConfig == nil {
+		t.Fatal("expected volume options to be set")
+	}
+	if !opts.NoCopy {
+		t.Fatal("expected nocopy to be set")
+	}
+	if opts.DriverConfig.Name != "mydriver" {
+		t.Fatalf("expected driver mydriver, got %s", opts.DriverConfig.Name)
+	}
+	if opts.DriverConfig.Options["size"] != "10G" || opts.DriverConfig.Options["fast"] != "" || len(opts.DriverConfig.Options) != 2 {
+		t.Fatalf("unexpected driver options %v", opts.DriverConfig.Options)
+	}
+	if opts.Labels["a"] != "b" || len(opts.Labels) != 1 {
+		t.Fatalf("unexpected labels %v", opts.Labels)
+	}
+}
+
+func TestMountOptTmpfsOptions(t *testing.T) {
+	var mount MountOpt
+	if err := mount.Set("type=tmpfs,target=/target,tmpfs-size=1m,tmpfs-mode=1777"); err != nil {
+		t.Fatal(err)
+	}
+	opts := mount.Value()[0].TmpfsOptions
+	if opts == nil {
+		t.Fatal("expected tmpfs options to be set")
+	}
+	if opts.SizeBytes != 1024*1024 {
+		t.Fatalf("expected size of 1m, got %d", opts.SizeBytes)
+	}
+	if opts.Mode != os.FileMode(01777) {
+		t.Fatalf("expected mode 1777, got %o", opts.Mode)
+	}
+}
+
+func TestMountOptErrors(t *testing.T) {
+	for value, expected := range map[string]string{
+		"type=volume":                                    "target is required",
+		"type=volume,target=/foo,invalid=foo":            "unexpected key 'invalid'",
+		"type=volume,target=/foo,invalid":                "invalid field 'invalid'",
+		"type=volume,target=/foo,readonly=maybe":         "invalid value for readonly",
+		"type=bind,target=/foo,volume-driver=local":      "cannot mix 'volume-*' options with mount type 'bind'",
+		"type=volume,target=/foo,bind-propagation=slave": "cannot mix 'bind-*' options with mount type 'volume'",
+		"type=bind,target=/foo,tmpfs-size=1m":            "cannot mix 'tmpfs-*' options with mount type 'bind'",
+		"type=tmpfs,target=/foo,tmpfs-size=foo":          "invalid value for tmpfs-size",
+		"type=tmpfs,target=/foo,tmpfs-mode=999":          "invalid value for tmpfs-mode",
+	} {
+		var mount MountOpt
+		err := mount.Set(value)
+		if err == nil {
+			t.Fatalf("expected error for %q", value)
+		}
+		if !strings.Contains(err.Error(), expected) {
+			t.Fatalf("expected error containing %q for %q, got %v", expected, value, err)
+		}
+	}
+}
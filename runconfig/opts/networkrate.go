@@ -0,0 +1,73 @@
+package opts
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/docker/go-units"
+)
+
+// NetworkRateOpt is a Value type for parsing the network rate of a container,
+// in the form `ingress=<rate>[,egress=<rate>]`. Rates are in bytes per
+// second, with an optional unit like `10mb`.
+type NetworkRateOpt struct {
+	ingress uint64
+	egress  uint64
+}
+
+// Set parses the rates of the value.
+func (o *NetworkRateOpt) Set(value string) error {
+	csvReader := csv.NewReader(strings.NewReader(value))
+	fields, err := csvReader.Read()
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid field '%s' must be a key=value pair", field)
+		}
+		rate, err := units.RAMInBytes(parts[1])
+		if err != nil {
+			return fmt.Errorf("invalid rate for %s: %v", parts[0], err)
+		}
+		if rate <= 0 {
+			return fmt.Errorf("invalid rate for %s: %s, the rate must be positive", parts[0], parts[1])
+		}
+		switch strings.ToLower(parts[0]) {
+		case "ingress":
+			o.ingress = uint64(rate)
+		case "egress":
+			o.egress = uint64(rate)
+		default:
+			return fmt.Errorf("unexpected key '%s' in '%s'", parts[0], field)
+		}
+	}
+	return nil
+}
+
+// String returns a string repr of this option
+func (o *NetworkRateOpt) String() string {
+	var rates []string
+	if o.ingress != 0 {
+		rates = append(rates, fmt.Sprintf("ingress=%s", units.BytesSize(float64(o.ingress))))
+	}
+	if o.egress != 0 {
+		rates = append(rates, fmt.Sprintf("egress=%s", units.BytesSize(float64(o.egress))))
+	}
+	return strings.Join(rates, ",")
+}
+
+// Ingress returns the rate of the traffic received by the container, in bytes
+// per second.
+func (o *NetworkRateOpt) Ingress() uint64 {
+	return o.ingress
+}
+
+// Egress returns the rate of the traffic sent by the container, in bytes per
+// second.
+func (o *NetworkRateOpt) Egress() uint64 {
+	return o.egress
+}
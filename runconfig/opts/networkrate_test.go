@@ -0,0 +1,45 @@
+package opts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNetworkRateOpt(t *testing.T) {
+	var rate NetworkRateOpt
+	if err := rate.Set("ingress=10mb,egress=512kb"); err != nil {
+		t.Fatal(err)
+	}
+	if rate.Ingress() != 10*1024*1024 {
+		t.Fatalf("expected an ingress rate of 10mb, got %d", rate.Ingress())
+	}
+	if rate.Egress() != 512*1024 {
+		t.Fatalf("expected an egress rate of 512kb, got %d", rate.Egress())
+	}
+	if rate.String() != "ingress=10 MiB,egress=512 KiB" {
+		t.Fatalf("unexpected string %q", rate.String())
+	}
+
+	rate = NetworkRateOpt{}
+	if err := rate.Set("egress=1000"); err != nil {
+		t.Fatal(err)
+	}
+	if rate.Ingress() != 0 || rate.Egress() != 1000 {
+		t.Fatalf("expected only an egress rate of 1000, got %d and %d", rate.Ingress(), rate.Egress())
+	}
+}
+
+func TestNetworkRateOptErrors(t *testing.T) {
+	for value, expectedError := range map[string]string{
+		"10mb":          "must be a key=value pair",
+		"ingress=fast":  "invalid rate for ingress",
+		"egress=0":      "the rate must be positive",
+		"upload=10mb":   "unexpected key 'upload'",
+		"ingress=-10mb": "invalid rate for ingress",
+	} {
+		var rate NetworkRateOpt
+		if err := rate.Set(value); err == nil || !strings.Contains(err.Error(), expectedError) {
+			t.Fatalf("expected error containing %q for %q, got %v", expectedError, value, err)
+		}
+	}
+}
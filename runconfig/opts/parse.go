@@ -30,6 +30,9 @@ func Parse(cmd *flag.FlagSet, args []string) (*container.Config, *container.Host
 		flAttach            = opts.NewListOpts(ValidateAttach)
 		flVolumes           = opts.NewListOpts(nil)
 		flTmpfs             = opts.NewListOpts(nil)
+		flMounts            MountOpt
+		flCPUs              opts.NanoCPUs
+		flNetworkRate       NetworkRateOpt
 		flBlkioWeightDevice = NewWeightdeviceOpt(ValidateWeightDevice)
 		flDeviceReadBps     = NewThrottledeviceOpt(ValidateThrottleBpsDevice)
 		flDeviceWriteBps    = NewThrottledeviceOpt(ValidateThrottleBpsDevice)
@@ -40,6 +43,7 @@ func Parse(cmd *flag.FlagSet, args []string) (*container.Config, *container.Host
 		flEnv               = opts.NewListOpts(ValidateEnv)
 		flLabels            = opts.NewListOpts(ValidateEnv)
 		flDevices           = opts.NewListOpts(ValidateDevice)
+		flDeviceCgroupRules = opts.NewListOpts(ValidateDeviceCgroupRule)
 
 		flUlimits = NewUlimitOpt(nil)
 		flSysctls = opts.NewMapOpts(nil, opts.ValidateSysctl)
@@ -97,7 +101,10 @@ func Parse(cmd *flag.FlagSet, args []string) (*container.Config, *container.Host
 		flCgroupParent      = cmd.String([]string{"-cgroup-parent"}, "", "Optional parent cgroup for the container")
 		flVolumeDriver      = cmd.String([]string{"-volume-driver"}, "", "Optional volume driver for the container")
 		flStopSignal        = cmd.String([]string{"-stop-signal"}, signal.DefaultStopSignal, fmt.Sprintf("Signal to stop a container, %v by default", signal.DefaultStopSignal))
+		flStopTimeout       = cmd.Int([]string{"-stop-timeout"}, 0, "Timeout (in seconds) to stop a container")
 		flIsolation         = cmd.String([]string{"-isolation"}, "", "Container isolation technology")
+		flRuntime           = cmd.String([]string{"-runtime"}, "", "Runtime to use for this container")
+		flInit              = cmd.Bool([]string{"-init"}, false, "Run an init inside the container that forwards signals and reaps processes")
 		flShmSize           = cmd.String([]string{"-shm-size"}, "", "Size of /dev/shm, default value is 64MB")
 	)
 
@@ -109,9 +116,13 @@ func Parse(cmd *flag.FlagSet, args []string) (*container.Config, *container.Host
 	cmd.Var(&flDeviceWriteIOps, []string{"-device-write-iops"}, "Limit write rate (IO per second) to a device")
 	cmd.Var(&flVolumes, []string{"v", "-volume"}, "Bind mount a volume")
 	cmd.Var(&flTmpfs, []string{"-tmpfs"}, "Mount a tmpfs directory")
+	cmd.Var(&flMounts, []string{"-mount"}, "Attach a filesystem mount to the container")
+	cmd.Var(&flCPUs, []string{"-cpus"}, "Number of CPUs")
+	cmd.Var(&flNetworkRate, []string{"-network-rate"}, "Limit the network rate (bytes per second) of the container (ingress=<rate>,egress=<rate>)")
 	cmd.Var(&flLinks, []string{"-link"}, "Add link to another container")
 	cmd.Var(&flAliases, []string{"-net-alias"}, "Add network-scoped alias for the container")
 	cmd.Var(&flDevices, []string{"-device"}, "Add a host device to the container")
+	cmd.Var(&flDeviceCgroupRules, []string{"-device-cgroup-rule"}, "Add a rule to the cgroup allowed devices list")
 	cmd.Var(&flLabels, []string{"l", "-label"}, "Set meta data on a container")
 	cmd.Var(&flLabelsFile, []string{"-label-file"}, "Read in a line delimited file of labels")
 	cmd.Var(&flEnv, []string{"e", "-env"}, "Set environment variables")
@@ -357,6 +368,7 @@ func Parse(cmd *flag.FlagSet, args []string) (*container.Config, *container.Host
 		OomKillDisable:       flOomKillDisable,
 		CPUPercent:           *flCPUPercent,
 		CPUShares:            *flCPUShares,
+		NanoCPUs:             flCPUs.Value(),
 		CPUPeriod:            *flCPUPeriod,
 		CpusetCpus:           *flCpusetCpus,
 		CpusetMems:           *flCpusetMems,
@@ -370,6 +382,9 @@ func Parse(cmd *flag.FlagSet, args []string) (*container.Config, *container.Host
 		BlkioDeviceWriteIOps: flDeviceWriteIOps.GetList(),
 		Ulimits:              flUlimits.GetList(),
 		Devices:              deviceMappings,
+		DeviceCgroupRules:    flDeviceCgroupRules.GetAll(),
+		NetworkIngressRate:   flNetworkRate.Ingress(),
+		NetworkEgressRate:    flNetworkRate.Egress(),
 	}
 
 	config := &container.Config{
@@ -397,6 +412,9 @@ func Parse(cmd *flag.FlagSet, args []string) (*container.Config, *container.Host
 	if cmd.IsSet("-stop-signal") {
 		config.StopSignal = *flStopSignal
 	}
+	if cmd.IsSet("-stop-timeout") {
+		config.StopTimeout = flStopTimeout
+	}
 
 	hostConfig := &container.HostConfig{
 		Binds:           binds,
@@ -434,7 +452,14 @@ func Parse(cmd *flag.FlagSet, args []string) (*container.Config, *container.Host
 		ShmSize:        shmSize,
 		Resources:      resources,
 		Tmpfs:          tmpfs,
+		Mounts:         flMounts.Value(),
 		Sysctls:        flSysctls.GetAll(),
+		Runtime:        *flRuntime,
+	}
+
+	// Only override the daemon's default when the flag is given
+	if cmd.IsSet("-init") {
+		hostConfig.Init = flInit
 	}
 
 	// When allocating stdin in attached mode, close stdin at client disconnect
@@ -684,6 +709,22 @@ func ValidDeviceMode(mode string) bool {
 	return true
 }
 
+// deviceCgroupRuleRegexp matches a rule of the devices cgroup, in the form
+// `type major:minor access`, e.g. `c 188:* rmw`.
+var deviceCgroupRuleRegexp = regexp.MustCompile(`^([acb]) ([0-9]+|\*):([0-9]+|\*) ([rwm]{1,3})$`)
+
+// ValidateDeviceCgroupRule validates a rule of the devices cgroup. It will
+// make sure 'val' is in the form:
+//    type major:minor access
+// where type is one of a (all), c (char) or b (block), major and minor are
+// device numbers or '*', and access is a combination of r, w and m.
+func ValidateDeviceCgroupRule(val string) (string, error) {
+	if !deviceCgroupRuleRegexp.MatchString(val) || !ValidDeviceMode(deviceCgroupRuleRegexp.FindStringSubmatch(val)[4]) {
+		return val, fmt.Errorf("invalid device cgroup rule format: '%s'", val)
+	}
+	return val, nil
+}
+
 // ValidateDevice validates a path for devices
 // It will make sure 'val' is in the form:
 //    [host-dir:]container-path[:mode]
@@ -358,6 +358,39 @@ func TestParseWithMacAddress(t *testing.T) {
 	}
 }
 
+func TestParseWithInit(t *testing.T) {
+	if _, hostconfig := mustParse(t, ""); hostconfig.Init != nil {
+		t.Fatalf("Expected Init to be unset, got %v", *hostconfig.Init)
+	}
+	if _, hostconfig := mustParse(t, "--init"); hostconfig.Init == nil || !*hostconfig.Init {
+		t.Fatalf("Expected Init to be true, got %v", hostconfig.Init)
+	}
+	if _, hostconfig := mustParse(t, "--init=false"); hostconfig.Init == nil || *hostconfig.Init {
+		t.Fatalf("Expected Init to be false, got %v", hostconfig.Init)
+	}
+}
+
+func TestParseWithStopTimeout(t *testing.T) {
+	if config, _ := mustParse(t, ""); config.StopTimeout != nil {
+		t.Fatalf("Expected StopTimeout to be unset, got %v", *config.StopTimeout)
+	}
+	if config, _ := mustParse(t, "--stop-timeout=60"); config.StopTimeout == nil || *config.StopTimeout != 60 {
+		t.Fatalf("Expected StopTimeout to be 60, got %v", config.StopTimeout)
+	}
+	if _, _, _, _, err := parseRun([]string{"--stop-timeout=invalid", "img", "cmd"}); err == nil {
+		t.Fatal("Expected an error with an invalid StopTimeout")
+	}
+}
+
+func TestParseWithCPUs(t *testing.T) {
+	if _, hostconfig := mustParse(t, "--cpus=1.5"); hostconfig.NanoCPUs != 15e8 {
+		t.Fatalf("Expected NanoCPUs to be 1500000000, got %v", hostconfig.NanoCPUs)
+	}
+	if _, _, _, _, err := parseRun([]string{"--cpus=invalid", "img", "cmd"}); err == nil {
+		t.Fatal("Expected an error with invalid CPUs")
+	}
+}
+
 func TestParseWithMemory(t *testing.T) {
 	invalidMemory := "--memory=invalid"
 	validMemory := "--memory=1G"
@@ -837,3 +870,34 @@ func TestVolumeSplitN(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateDeviceCgroupRule(t *testing.T) {
+	valid := []string{
+		"c 188:* rmw",
+		"b 8:0 r",
+		"a *:* rwm",
+		"c 1:3 mr",
+	}
+	invalid := []string{
+		"",
+		"c 188:*",
+		"c 188 rmw",
+		"x 188:* rmw",
+		"c 188:* rmwx",
+		"c 188:* rr",
+		"c a:b rmw",
+		" c 188:* rmw",
+	}
+
+	for _, rule := range valid {
+		if _, err := ValidateDeviceCgroupRule(rule); err != nil {
+			t.Fatalf("ValidateDeviceCgroupRule(`%q`) should succeed: error %q", rule, err)
+		}
+	}
+
+	for _, rule := range invalid {
+		if _, err := ValidateDeviceCgroupRule(rule); err == nil {
+			t.Fatalf("ValidateDeviceCgroupRule(`%q`) should have failed validation", rule)
+		}
+	}
+}
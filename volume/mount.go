@@ -0,0 +1,152 @@
+package volume
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	mounttypes "github.com/docker/engine-api/types/mount"
+)
+
+// ParseMount validates a typed mount and converts it into a mount point.
+// Mounts of type tmpfs are not backed by a mount point, they are only
+// validated and a nil mount point is returned for them.
+func ParseMount(m mounttypes.Mount, volumeDriver string) (*MountPoint, error) {
+	if err := validateMount(m); err != nil {
+		return nil, err
+	}
+
+	mp := &MountPoint{
+		RW:          !m.ReadOnly,
+		Destination: filepath.Clean(m.Target),
+	}
+
+	switch m.Type {
+	case mounttypes.TypeBind:
+		mp.Source = filepath.Clean(m.Source)
+		mp.Propagation = DefaultPropagationMode
+		if m.BindOptions != nil && m.BindOptions.Propagation != "" {
+			mp.Propagation = string(m.BindOptions.Propagation)
+		}
+	case mounttypes.TypeVolume:
+		mp.Name = m.Source
+		mp.Driver = volumeDriver
+		mp.CopyData = DefaultCopyMode
+		if opts := m.VolumeOptions; opts != nil {
+			if opts.DriverConfig != nil && opts.DriverConfig.Name != "" {
+				mp.Driver = opts.DriverConfig.Name
+			}
+			if opts.NoCopy {
+				mp.CopyData = false
+			}
+		}
+	case mounttypes.TypeTmpfs:
+		return nil, nil
+	}
+	return mp, nil
+}
+
+// ConvertTmpfsOptions converts the options of a typed tmpfs mount into the
+// data string passed to mount(2).
+func ConvertTmpfsOptions(opts *mounttypes.TmpfsOptions, readOnly bool) string {
+	var data []string
+	if readOnly {
+		data = append(data, "ro")
+	}
+	if opts != nil {
+		if opts.SizeBytes > 0 {
+			data = append(data, fmt.Sprintf("size=%d", opts.SizeBytes))
+		}
+		if opts.Mode != 0 {
+			data = append(data, fmt.Sprintf("mode=%o", opts.Mode))
+		}
+	}
+	return strings.Join(data, ",")
+}
+
+func errInvalidMount(m mounttypes.Mount, format string, args ...interface{}) error {
+	return fmt.Errorf("invalid mount config for type %q: %s", m.Type, fmt.Sprintf(format, args...))
+}
+
+func errExtraField(m mounttypes.Mount, field string) error {
+	return errInvalidMount(m, "field %s must not be specified", field)
+}
+
+func validateMount(m mounttypes.Mount) error {
+	if len(m.Target) == 0 {
+		return errInvalidMount(m, "field Target must not be empty")
+	}
+	target := filepath.Clean(m.Target)
+	if !filepath.IsAbs(target) {
+		return errInvalidMount(m, "mount path '%s' must be absolute", m.Target)
+	}
+	if runtime.GOOS != "windows" && target == "/" {
+		return errInvalidMount(m, "destination can't be '/'")
+	}
+
+	switch m.Type {
+	case mounttypes.TypeBind:
+		if m.VolumeOptions != nil {
+			return errExtraField(m, "VolumeOptions")
+		}
+		if m.TmpfsOptions != nil {
+			return errExtraField(m, "TmpfsOptions")
+		}
+		if len(m.Source) == 0 {
+			return errInvalidMount(m, "field Source must not be empty")
+		}
+		if !filepath.IsAbs(m.Source) {
+			return errInvalidMount(m, "bind source path '%s' must be absolute", m.Source)
+		}
+		if _, err := os.Stat(m.Source); err != nil {
+			if !os.IsNotExist(err) {
+				return err
+			}
+			return errInvalidMount(m, "bind source path does not exist: %s", m.Source)
+		}
+		if m.BindOptions != nil && m.BindOptions.Propagation != "" {
+			if !propagationModes[string(m.BindOptions.Propagation)] {
+				return errInvalidMount(m, "invalid propagation mode '%s'", m.BindOptions.Propagation)
+			}
+		}
+	case mounttypes.TypeVolume:
+		if m.BindOptions != nil {
+			return errExtraField(m, "BindOptions")
+		}
+		if m.TmpfsOptions != nil {
+			return errExtraField(m, "TmpfsOptions")
+		}
+		if len(m.Source) > 0 {
+			if filepath.IsAbs(m.Source) {
+				return errInvalidMount(m, "volume name '%s' must not be a path", m.Source)
+			}
+			if valid, err := IsVolumeNameValid(m.Source); !valid {
+				if err == nil {
+					err = fmt.Errorf("invalid volume name '%s'", m.Source)
+				}
+				return errInvalidMount(m, "%v", err)
+			}
+		}
+	case mounttypes.TypeTmpfs:
+		if runtime.GOOS == "windows" {
+			return errInvalidMount(m, "tmpfs mounts are not supported on this platform")
+		}
+		if m.BindOptions != nil {
+			return errExtraField(m, "BindOptions")
+		}
+		if m.VolumeOptions != nil {
+			return errExtraField(m, "VolumeOptions")
+		}
+		if len(m.Source) > 0 {
+			return errExtraField(m, "Source")
+		}
+		if m.TmpfsOptions != nil && m.TmpfsOptions.SizeBytes < 0 {
+			return errInvalidMount(m, "invalid size %d", m.TmpfsOptions.SizeBytes)
+		}
+	default:
+		return fmt.Errorf("invalid mount type: %q", m.Type)
+	}
+	return nil
+}
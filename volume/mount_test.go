@@ -0,0 +1,112 @@
+package volume
+
+import (
+	"io/ioutil"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+
+	mounttypes "github.com/docker/engine-api/types/mount"
+)
+
+func TestParseMount(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows paths are covered by TestParseMountSpec")
+	}
+	source, err := ioutil.TempDir("", "test-parse-mount")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(source)
+
+	cases := []struct {
+		mount    mounttypes.Mount
+		expected MountPoint
+	}{
+		{
+			mounttypes.Mount{Type: mounttypes.TypeBind, Source: source, Target: "/foo"},
+			MountPoint{Source: source, Destination: "/foo", RW: true, Propagation: DefaultPropagationMode},
+		},
+		{
+			mounttypes.Mount{Type: mounttypes.TypeBind, Source: source, Target: "/foo/", ReadOnly: true, BindOptions: &mounttypes.BindOptions{Propagation: mounttypes.PropagationShared}},
+			MountPoint{Source: source, Destination: "/foo", RW: false, Propagation: "shared"},
+		},
+		{
+			mounttypes.Mount{Type: mounttypes.TypeVolume, Target: "/foo"},
+			MountPoint{Destination: "/foo", RW: true, Driver: "local", CopyData: true},
+		},
+		{
+			mounttypes.Mount{Type: mounttypes.TypeVolume, Source: "myvolume", Target: "/foo", VolumeOptions: &mounttypes.VolumeOptions{NoCopy: true, DriverConfig: &mounttypes.Driver{Name: "other"}}},
+			MountPoint{Name: "myvolume", Destination: "/foo", RW: true, Driver: "other"},
+		},
+	}
+
+	for _, c := range cases {
+		mp, err := ParseMount(c.mount, "local")
+		if err != nil {
+			t.Fatalf("unexpected error for %+v: %v", c.mount, err)
+		}
+		if *mp != c.expected {
+			t.Fatalf("expected %+v for %+v, got %+v", c.expected, c.mount, *mp)
+		}
+	}
+
+	mp, err := ParseMount(mounttypes.Mount{Type: mounttypes.TypeTmpfs, Target: "/tmp"}, "local")
+	if err != nil || mp != nil {
+		t.Fatalf("expected no mount point and no error for tmpfs, got %v, %v", mp, err)
+	}
+}
+
+func TestParseMountInvalid(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows paths are covered by TestParseMountSpec")
+	}
+
+	cases := []struct {
+		mount    mounttypes.Mount
+		expected string
+	}{
+		{mounttypes.Mount{Type: "invalid", Target: "/foo"}, "invalid mount type"},
+		{mounttypes.Mount{Type: mounttypes.TypeVolume}, "Target must not be empty"},
+		{mounttypes.Mount{Type: mounttypes.TypeVolume, Target: "foo"}, "must be absolute"},
+		{mounttypes.Mount{Type: mounttypes.TypeVolume, Target: "/"}, "destination can't be '/'"},
+		{mounttypes.Mount{Type: mounttypes.TypeBind, Target: "/foo"}, "Source must not be empty"},
+		{mounttypes.Mount{Type: mounttypes.TypeBind, Source: "foo", Target: "/foo"}, "must be absolute"},
+		{mounttypes.Mount{Type: mounttypes.TypeBind, Source: "/does/not/exist", Target: "/foo"}, "bind source path does not exist"},
+		{mounttypes.Mount{Type: mounttypes.TypeBind, Source: "/", Target: "/foo", VolumeOptions: &mounttypes.VolumeOptions{}}, "VolumeOptions must not be specified"},
+		{mounttypes.Mount{Type: mounttypes.TypeBind, Source: "/", Target: "/foo", BindOptions: &mounttypes.BindOptions{Propagation: "invalid"}}, "invalid propagation mode"},
+		{mounttypes.Mount{Type: mounttypes.TypeVolume, Source: "/foo", Target: "/foo"}, "must not be a path"},
+		{mounttypes.Mount{Type: mounttypes.TypeVolume, Target: "/foo", TmpfsOptions: &mounttypes.TmpfsOptions{}}, "TmpfsOptions must not be specified"},
+		{mounttypes.Mount{Type: mounttypes.TypeTmpfs, Source: "foo", Target: "/foo"}, "Source must not be specified"},
+		{mounttypes.Mount{Type: mounttypes.TypeTmpfs, Target: "/foo", TmpfsOptions: &mounttypes.TmpfsOptions{SizeBytes: -1}}, "invalid size"},
+	}
+
+	for _, c := range cases {
+		_, err := ParseMount(c.mount, "local")
+		if err == nil {
+			t.Fatalf("expected error for %+v", c.mount)
+		}
+		if !strings.Contains(err.Error(), c.expected) {
+			t.Fatalf("expected error containing %q for %+v, got %v", c.expected, c.mount, err)
+		}
+	}
+}
+
+func TestConvertTmpfsOptions(t *testing.T) {
+	cases := []struct {
+		opts     *mounttypes.TmpfsOptions
+		readOnly bool
+		expected string
+	}{
+		{nil, false, ""},
+		{nil, true, "ro"},
+		{&mounttypes.TmpfsOptions{SizeBytes: 1024 * 1024}, false, "size=1048576"},
+		{&mounttypes.TmpfsOptions{SizeBytes: 1024, Mode: 01777}, true, "ro,size=1024,mode=1777"},
+	}
+	for _, c := range cases {
+		if data := ConvertTmpfsOptions(c.opts, c.readOnly); data != c.expected {
+			t.Fatalf("expected %q for %+v, got %q", c.expected, c.opts, data)
+		}
+	}
+}